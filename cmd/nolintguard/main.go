@@ -1,6 +1,9 @@
 // Command nolintguard is a standalone runner for the nolintguard analyzer.
 //
-// It can be used to run the linter independently without golangci-lint.
+// It can be used to run the linter independently without golangci-lint. The
+// policy is read from the nearest .nolintguard.yml or .nolintguard.json file
+// above each package directory, and flags set on the command line take
+// precedence over it.
 //
 // Usage:
 //
@@ -19,6 +22,12 @@
 //
 //	# With forbidden linters
 //	nolintguard -forbidden-linters=staticcheck,unused ./...
+//
+//	# Allow only specific linters
+//	nolintguard -allowed-linters=lll,errcheck,funlen ./...
+//
+//	# Use an explicit policy file instead of the nearest .nolintguard.yml
+//	nolintguard -config=ci/nolintguard.yml ./...
 package main
 
 import (
@@ -50,5 +59,5 @@ func main() {
 	// Add custom version flag.
 	flag.Bool("version", false, "print version and exit")
 
-	singlechecker.Main(nolintguard.Analyzer)
+	singlechecker.Main(nolintguard.NewAnalyzerWithConfigFiles())
 }
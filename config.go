@@ -0,0 +1,381 @@
+package nolintguard
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Config holds the configuration options for the nolintguard analyzer.
+type Config struct {
+	// RequireJustification, when true, requires security suppression directives
+	// (#nosec, //gosec:, //revive:) to include a justification comment.
+	RequireJustification bool
+
+	// ForbiddenLinters is a map of linter names that should be forbidden
+	// in //nolint directives (e.g., staticcheck, unused).
+	ForbiddenLinters map[string]bool
+
+	// AllowedLinters, when non-empty, turns //nolint checking into allowlist mode:
+	// only the listed linters may appear in //nolint directives. gosec and revive
+	// are always forbidden and cannot be allowed.
+	AllowedLinters map[string]bool
+
+	// ForbidBareNolint, when true, reports //nolint directives without a linter list.
+	ForbidBareNolint bool
+
+	// ForbidEmptyNolint, when true, reports //nolint: directives whose linter list is empty.
+	ForbidEmptyNolint bool
+
+	// ForbidNolintAll, when true, reports //nolint:all directives.
+	ForbidNolintAll bool
+
+	// ValidateLinterNames, when true, reports linter names in //nolint directives
+	// that are unknown to golangci-lint, suggesting the closest known name.
+	ValidateLinterNames bool
+
+	// CustomLinters lists additional linter names (e.g., private plugins) that
+	// ValidateLinterNames accepts.
+	CustomLinters map[string]bool
+
+	// RequireNolintExplanation, when true, requires every //nolint directive to
+	// include a golangci-lint style explanation (//nolint:linter // reason).
+	RequireNolintExplanation bool
+
+	// RequireNolintExplanationFor lists linters whose //nolint directives must
+	// include an explanation even when RequireNolintExplanation is false.
+	RequireNolintExplanationFor map[string]bool
+
+	// RequireGosecRuleIDs, when true, requires #nosec and //gosec:disable directives
+	// to list the gosec rules they suppress (e.g., #nosec G401). Rule IDs must be
+	// known to gosec and separated by spaces or commas.
+	RequireGosecRuleIDs bool
+
+	// ForbiddenGosecRules lists gosec rule IDs (e.g., G101, G402) that #nosec and
+	// //gosec:disable directives must never suppress, whatever the justification.
+	// Blanket suppressions are reported as well when the code they are attached to
+	// looks like it triggers one of these rules (G101, G201, G202 and G402 only).
+	ForbiddenGosecRules map[string]bool
+
+	// ValidateReviveRules, when true, reports rule names in //revive: directives
+	// that are unknown to revive, suggesting the closest known name.
+	ValidateReviveRules bool
+
+	// ForbiddenReviveRules lists revive rules (e.g., exported) that //revive:disable
+	// directives must never disable. Directives disabling every rule are reported
+	// as well, since they disable these rules too.
+	ForbiddenReviveRules map[string]bool
+
+	// CheckReviveRegions, when true, reports //revive:disable directives that are
+	// never re-enabled in the same file, //revive:enable directives without a
+	// preceding disable and redundant nested disables of the same rule.
+	CheckReviveRegions bool
+
+	// MaxNolintLines, when positive, limits the number of lines a //nolint
+	// directive may suppress. A directive on its own line suppresses the node
+	// that starts on the next line, e.g. a whole function declaration.
+	MaxNolintLines int
+
+	// MaxNosecLines, when positive, limits the number of lines a #nosec or
+	// //gosec: directive may suppress, i.e. the extent of the node it is
+	// attached to.
+	MaxNosecLines int
+
+	// MaxReviveLines, when positive, limits the number of lines between a
+	// //revive:disable directive and the //revive:enable closing it.
+	MaxReviveLines int
+
+	// ForbidFileLevelSuppressions, when true, reports directives whose scope is
+	// the whole file: //nolint directly above the package clause, #nosec and
+	// //gosec: in the package doc comment, and //revive:disable regions opened
+	// before the first declaration and never re-enabled.
+	ForbidFileLevelSuppressions bool
+
+	// FileLevelAllowedLinters lists the linters (gosec and revive included) that
+	// ForbidFileLevelSuppressions still allows to be suppressed for the whole file.
+	FileLevelAllowedLinters map[string]bool
+
+	// CheckNolintPlacement, when true, reports //nolint directives on their own
+	// line that golangci-lint attaches to no node (e.g. followed by a blank line
+	// or at the end of a block) and directives attached to a whole compound
+	// statement (if, for, switch, select or block) inside a function.
+	CheckNolintPlacement bool
+
+	// GolangciCompat, when true, reproduces golangci-lint's //nolint recognition:
+	// directives golangci-lint ignores (e.g. /* nolint:x */ or //nolint:a //nolint:b)
+	// suppress nothing, so they are reported as ineffective, with a fix to the
+	// canonical //nolint:x form, instead of being checked against the policy.
+	GolangciCompat bool
+
+	// NosecTag is an alternative tag that gosec honours in place of #nosec, as
+	// configured with gosec's -nosec-tag flag (e.g., #falsepositive). The leading
+	// # is optional. Directives using it are checked like #nosec directives.
+	NosecTag string
+
+	// GeneratedFiles selects how files with a "// Code generated ... DO NOT EDIT."
+	// marker are handled: GeneratedFilesCheck (the default when empty) applies the
+	// policy like for any other file, GeneratedFilesSkip ignores them, and
+	// GeneratedFilesReport reports every suppression in them, since a directive
+	// the generator did not emit means the file was edited by hand.
+	GeneratedFiles string
+
+	// ScanIgnoredFiles, when true, also checks the files of the package excluded
+	// by build constraints for the current build (e.g. //go:build windows or
+	// custom tags), so that platform-specific files cannot hide suppressions.
+	ScanIgnoredFiles bool
+
+	// Overrides adjust the configuration for files whose path matches a glob
+	// pattern, e.g. to forbid more suppressions below internal/payments or fewer
+	// below tools. Matching overrides are applied in order, so when several match,
+	// the later ones take precedence for the fields they set.
+	Overrides []Override
+
+	// TestFiles, when set, adjusts the configuration for test files: files whose
+	// name ends in _test.go and files of an external _test package. When its Paths
+	// are empty, it applies to every test file; otherwise only to the test files
+	// they match. It is applied before Overrides, which can refine it further for
+	// test files matching their patterns (e.g., internal/payments/**/*_test.go).
+	TestFiles *Override
+
+	// combinations caches the validation of the configurations yielded by
+	// several overrides matching the same file
+	combinations *overrideCombinations
+
+	// policyDir is the directory of the policy file the configuration was read
+	// from, which override patterns are relative to, or empty for the module root.
+	policyDir string
+}
+
+// Modes for Config.GeneratedFiles.
+const (
+	GeneratedFilesCheck  = "check"
+	GeneratedFilesSkip   = "skip"
+	GeneratedFilesReport = "report"
+)
+
+// normalize validates the configuration and returns a copy that does not
+// share any maps with the original.
+func (c Config) normalize() (Config, error) {
+	var err error
+
+	if c.ForbiddenLinters, err = copyLinterSet("ForbiddenLinters", c.ForbiddenLinters); err != nil {
+		return Config{}, err
+	}
+	if c.AllowedLinters, err = copyLinterSet("AllowedLinters", c.AllowedLinters); err != nil {
+		return Config{}, err
+	}
+	if c.CustomLinters, err = copyLinterSet("CustomLinters", c.CustomLinters); err != nil {
+		return Config{}, err
+	}
+	if c.RequireNolintExplanationFor, err = copyLinterSet("RequireNolintExplanationFor", c.RequireNolintExplanationFor); err != nil {
+		return Config{}, err
+	}
+
+	if c.ForbiddenGosecRules, err = copyLinterSet("ForbiddenGosecRules", c.ForbiddenGosecRules); err != nil {
+		return Config{}, err
+	}
+
+	if c.ForbiddenReviveRules, err = copyLinterSet("ForbiddenReviveRules", c.ForbiddenReviveRules); err != nil {
+		return Config{}, err
+	}
+	if c.FileLevelAllowedLinters, err = copyLinterSet("FileLevelAllowedLinters", c.FileLevelAllowedLinters); err != nil {
+		return Config{}, err
+	}
+
+	// Directives are checked against canonical linter names, so deprecated
+	// names in the configuration are resolved the same way
+	for _, set := range []map[string]bool{c.ForbiddenLinters, c.AllowedLinters, c.RequireNolintExplanationFor, c.FileLevelAllowedLinters} {
+		canonicalizeLinters(set)
+	}
+
+	if c.Overrides != nil {
+		overrides := make([]Override, len(c.Overrides))
+		for i, o := range c.Overrides {
+			if overrides[i], err = o.copy(fmt.Sprintf("Overrides[%d]", i)); err != nil {
+				return Config{}, err
+			}
+		}
+		c.Overrides = overrides
+	}
+
+	if c.TestFiles != nil {
+		testFiles, err := c.TestFiles.copy("TestFiles")
+		if err != nil {
+			return Config{}, err
+		}
+		c.TestFiles = &testFiles
+	}
+
+	if err := c.validate(); err != nil {
+		return Config{}, err
+	}
+	c.combinations = newOverrideCombinations()
+
+	return c, nil
+}
+
+// validate reports conflicts between configuration options.
+func (c Config) validate() error {
+	for _, rule := range slices.Sorted(maps.Keys(c.ForbiddenGosecRules)) {
+		if !gosecRuleIDRe.MatchString(rule) {
+			return fmt.Errorf("nolintguard: invalid configuration: ForbiddenGosecRules entry %q is not a gosec rule ID (e.g., G101)", rule)
+		}
+	}
+
+	for _, limit := range []struct {
+		field string
+		lines int
+	}{
+		{"MaxNolintLines", c.MaxNolintLines},
+		{"MaxNosecLines", c.MaxNosecLines},
+		{"MaxReviveLines", c.MaxReviveLines},
+	} {
+		if limit.lines < 0 {
+			return fmt.Errorf("nolintguard: invalid configuration: %s must not be negative", limit.field)
+		}
+	}
+
+	if tag := strings.TrimPrefix(c.NosecTag, "#"); c.NosecTag != "" {
+		if tag == "" || strings.ContainsAny(tag, " \t#") || strings.Contains(tag, "//") {
+			return fmt.Errorf("nolintguard: invalid configuration: NosecTag %q must be a single word", c.NosecTag)
+		}
+	}
+
+	switch c.GeneratedFiles {
+	case "", GeneratedFilesCheck, GeneratedFilesSkip, GeneratedFilesReport:
+	default:
+		return fmt.Errorf("nolintguard: invalid configuration: GeneratedFiles %q must be %q, %q or %q", c.GeneratedFiles, GeneratedFilesCheck, GeneratedFilesSkip, GeneratedFilesReport)
+	}
+
+	for _, linter := range slices.Sorted(maps.Keys(c.AllowedLinters)) {
+		if !c.AllowedLinters[linter] {
+			continue
+		}
+		if linter == "gosec" || linter == "revive" {
+			return fmt.Errorf("nolintguard: invalid configuration: %s cannot be allowed; //nolint:%s is always forbidden", linter, linter)
+		}
+		if c.ForbiddenLinters[linter] {
+			return fmt.Errorf("nolintguard: invalid configuration: %s is listed in both forbidden and allowed linters", linter)
+		}
+	}
+
+	// Each override must yield a valid configuration on its own; combinations of
+	// overrides are checked once, for the first file they match (see forFile)
+	for i, o := range c.Overrides {
+		field := fmt.Sprintf("Overrides[%d]", i)
+		if len(o.Paths) == 0 {
+			return fmt.Errorf("nolintguard: invalid configuration: %s has no Paths", field)
+		}
+		if err := c.validateOverride(field, o); err != nil {
+			return err
+		}
+	}
+
+	if c.TestFiles != nil {
+		if err := c.validateOverride("TestFiles", *c.TestFiles); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateOverride reports malformed path patterns in o and conflicts in the
+// configuration o yields when applied to c. field names o in error messages.
+func (c Config) validateOverride(field string, o Override) error {
+	for _, pattern := range o.Paths {
+		if err := validatePathPattern(pattern); err != nil {
+			return fmt.Errorf("nolintguard: invalid configuration: %s: %w", field, err)
+		}
+	}
+
+	applied := o.apply(c)
+	applied.Overrides, applied.TestFiles = nil, nil
+	if err := applied.validate(); err != nil {
+		return fmt.Errorf("%w (in %s)", err, field)
+	}
+
+	return nil
+}
+
+// nosecTag returns the alternative nosec tag with its leading #, or an empty
+// string if none is configured. Like gosec, the # is added when missing.
+func (c Config) nosecTag() string {
+	tag := "#" + strings.TrimPrefix(c.NosecTag, "#")
+	if tag == "#" || tag == "#nosec" {
+		return ""
+	}
+
+	return tag
+}
+
+// copyLinterSet validates the linter names in set and returns a copy holding only
+// the enabled entries. field names the Config field in error messages.
+func copyLinterSet(field string, set map[string]bool) (map[string]bool, error) {
+	linters := make(map[string]bool, len(set))
+	for linter, enabled := range set {
+		if err := validateLinterName(linter); err != nil {
+			return nil, fmt.Errorf("nolintguard: invalid %s entry: %w", field, err)
+		}
+		if enabled {
+			linters[linter] = true
+		}
+	}
+
+	return linters, nil
+}
+
+// canonicalizeLinters replaces deprecated linter names in set with their canonical
+// names (e.g., gosimple with staticcheck), so that they match the names directives
+// are checked against.
+func canonicalizeLinters(set map[string]bool) {
+	for _, linter := range slices.Sorted(maps.Keys(set)) {
+		if canonical, deprecated := canonicalLinter(linter); deprecated {
+			set[canonical] = set[canonical] || set[linter]
+			delete(set, linter)
+		}
+	}
+}
+
+// validateLinterName reports whether name can appear in a //nolint linter list.
+func validateLinterName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("linter name is empty")
+	}
+	if strings.ContainsAny(name, ", \t") {
+		return fmt.Errorf("%q must be a single linter name", name)
+	}
+
+	return nil
+}
+
+// linterSetFlag is a flag.Value that parses a comma-separated list of linter
+// names into a set once, when the flag is set. Deprecated linter names are
+// replaced with their canonical names when canonical is set.
+type linterSetFlag struct {
+	set       map[string]bool
+	canonical bool
+}
+
+// String returns the linters in the set as a sorted comma-separated list.
+func (f linterSetFlag) String() string {
+	return strings.Join(slices.Sorted(maps.Keys(f.set)), ",")
+}
+
+// Set replaces the contents of the set with the linters listed in value.
+func (f linterSetFlag) Set(value string) error {
+	clear(f.set)
+
+	for linter := range strings.SplitSeq(value, ",") {
+		linter = strings.TrimSpace(linter)
+		if linter != "" {
+			f.set[linter] = true
+		}
+	}
+	if f.canonical {
+		canonicalizeLinters(f.set)
+	}
+
+	return nil
+}
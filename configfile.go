@@ -0,0 +1,253 @@
+package nolintguard
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"go.yaml.in/yaml/v3"
+	"golang.org/x/tools/go/analysis"
+)
+
+// configFileNames lists the policy file names looked up in each directory, in
+// order of preference. The file holds the same keys as Settings.
+var configFileNames = []string{".nolintguard.yml", ".nolintguard.yaml", ".nolintguard.json"}
+
+// configFiles resolves the configuration of the flag-driven analyzer for each
+// package: the policy file named by -config, or else the nearest one found by
+// walking up from the package directory, with the flags set on the command line
+// applied on top. Without a policy file, the flags alone apply.
+type configFiles struct {
+	// flags is the configuration the analyzer flags are bound to
+	flags *Config
+
+	// resolveFlags resolves the configuration when there is no policy file
+	resolveFlags func(*analysis.Pass) (Config, error)
+
+	// path is the policy file named by -config
+	path string
+
+	// set records the names of the flags set explicitly
+	set map[string]flag.Value
+
+	mu sync.Mutex
+
+	// dirs maps package directories to their policy file, "" when there is none
+	dirs map[string]string
+
+	// loaded maps policy files to the configuration resolved from them
+	loaded map[string]loadedConfig
+}
+
+// loadedConfig is the outcome of loading a policy file.
+type loadedConfig struct {
+	config Config
+	err    error
+}
+
+// newConfigFiles returns a resolver for the analyzer whose flags are bound to flags.
+func newConfigFiles(flags *Config) *configFiles {
+	return &configFiles{
+		flags:        flags,
+		resolveFlags: flagResolver(flags),
+		set:          make(map[string]flag.Value),
+		dirs:         make(map[string]string),
+		loaded:       make(map[string]loadedConfig),
+	}
+}
+
+// trackFlags wraps every flag defined on fs so that setting it is recorded.
+// Drivers such as singlechecker register the flag values on their own flag set,
+// so fs itself cannot tell which flags were set.
+func (c *configFiles) trackFlags(fs *flag.FlagSet) {
+	fs.VisitAll(func(f *flag.Flag) {
+		f.Value = trackedFlag{Value: f.Value, name: f.Name, set: c.set}
+	})
+}
+
+// config returns the configuration for the package analyzed by pass.
+func (c *configFiles) config(pass *analysis.Pass) (Config, error) {
+	path, err := c.configFile(pass)
+	if err != nil {
+		return Config{}, err
+	}
+	if path == "" {
+		return c.resolveFlags(pass)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	loaded, ok := c.loaded[path]
+	if !ok {
+		loaded.config, loaded.err = c.load(path)
+		c.loaded[path] = loaded
+	}
+
+	return loaded.config, loaded.err
+}
+
+// configFile returns the policy file applying to the package analyzed by pass,
+// or "" if there is none.
+func (c *configFiles) configFile(pass *analysis.Pass) (string, error) {
+	if c.path != "" {
+		return c.path, nil
+	}
+	if len(pass.Files) == 0 {
+		return "", nil
+	}
+
+	dir, err := filepath.Abs(filepath.Dir(pass.Fset.File(pass.Files[0].Pos()).Name()))
+	if err != nil {
+		return "", fmt.Errorf("nolintguard: locating policy file: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	path, ok := c.dirs[dir]
+	if !ok {
+		path, err = findConfigFile(dir)
+		if err != nil {
+			return "", err
+		}
+		c.dirs[dir] = path
+	}
+
+	return path, nil
+}
+
+// load reads the policy file at path, applies the flags set explicitly on top and
+// validates the result.
+func (c *configFiles) load(path string) (Config, error) {
+	settings, err := readConfigFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+
+	config, err := settings.Config()
+	if err != nil {
+		return Config{}, fmt.Errorf("%w (in %s)", err, path)
+	}
+
+	// Override patterns in a discovered file are relative to its directory; a
+	// file named by -config may live anywhere, so they stay relative to the
+	// module root
+	if c.path == "" {
+		config.policyDir = filepath.Dir(path)
+	}
+
+	// Re-apply the explicitly set flags to the configuration from the file.
+	// Defining the flags resets the fields they are bound to, so the
+	// configuration is restored before the flags are set.
+	flags := flag.NewFlagSet(analyzerName, flag.ContinueOnError)
+	fromFile := config
+	registerFlags(flags, &config)
+	config = fromFile
+	for _, name := range slices.Sorted(maps.Keys(c.set)) {
+		if err := flags.Set(name, c.set[name].String()); err != nil {
+			return Config{}, fmt.Errorf("nolintguard: -%s: %w", name, err)
+		}
+	}
+
+	if err := config.validate(); err != nil {
+		return Config{}, fmt.Errorf("%w (in %s)", err, path)
+	}
+	config.combinations = newOverrideCombinations()
+
+	return config, nil
+}
+
+// findConfigFile returns the nearest policy file in dir or its parents, or "" if
+// there is none. The search stops at the module root, the first directory
+// holding a go.mod file.
+func findConfigFile(dir string) (string, error) {
+	for {
+		for _, name := range configFileNames {
+			path := filepath.Join(dir, name)
+			if ok, err := fileExists(path); ok || err != nil {
+				return path, err
+			}
+		}
+
+		if ok, err := fileExists(filepath.Join(dir, "go.mod")); ok || err != nil {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// fileExists reports whether path names an existing file.
+func fileExists(path string) (bool, error) {
+	_, err := os.Stat(path)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, fs.ErrNotExist):
+		return false, nil
+	default:
+		return false, fmt.Errorf("nolintguard: locating policy file: %w", err)
+	}
+}
+
+// readConfigFile decodes the policy file at path: JSON for a .json file, YAML
+// otherwise. Unknown keys and values of the wrong type are rejected.
+func readConfigFile(path string) (Settings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Settings{}, fmt.Errorf("nolintguard: reading policy file: %w", err)
+	}
+
+	var raw any
+	if filepath.Ext(path) == ".json" {
+		err = json.Unmarshal(data, &raw)
+	} else {
+		err = yaml.Unmarshal(data, &raw)
+	}
+	if err != nil {
+		return Settings{}, fmt.Errorf("nolintguard: parsing %s: %w", path, err)
+	}
+
+	settings, err := DecodeSettings(raw)
+	if err != nil {
+		return Settings{}, fmt.Errorf("%w (in %s)", err, path)
+	}
+
+	return settings, nil
+}
+
+// trackedFlag is a flag.Value recording in set that it was set.
+type trackedFlag struct {
+	flag.Value
+
+	name string
+	set  map[string]flag.Value
+}
+
+// Set sets the wrapped value and records the flag as set.
+func (f trackedFlag) Set(value string) error {
+	if err := f.Value.Set(value); err != nil {
+		return err
+	}
+	f.set[f.name] = f.Value
+
+	return nil
+}
+
+// IsBoolFlag lets boolean flags be set without a value, like the wrapped flag.
+func (f trackedFlag) IsBoolFlag() bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
@@ -0,0 +1,129 @@
+package nolintguard_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/go-extras/nolintguard"
+)
+
+func TestConfigFile(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	t.Run("discovered policy file", func(t *testing.T) {
+		// Test the .nolintguard.yml file found next to the package
+		analyzer := nolintguard.NewAnalyzerWithConfigFiles()
+		analysistest.Run(t, testdata, analyzer, "policy")
+	})
+
+	t.Run("flags override policy file", func(t *testing.T) {
+		// Test flags taking precedence over the .nolintguard.json file
+		analyzer := nolintguard.NewAnalyzerWithConfigFiles()
+		err := analyzer.Flags.Set("forbidden-linters", "unused")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "policyflags")
+	})
+
+	t.Run("explicit policy file", func(t *testing.T) {
+		// Test -config replacing the discovered file
+		analyzer := nolintguard.NewAnalyzerWithConfigFiles()
+		err := analyzer.Flags.Set("config", filepath.Join(testdata, "src", "policyflags", ".nolintguard.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = analyzer.Flags.Set("forbidden-linters", "unused")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "policyflags")
+	})
+
+	t.Run("override patterns relative to the policy file", func(t *testing.T) {
+		// Test the overrides in the .nolintguard.yml file above the package
+		analyzer := nolintguard.NewAnalyzerWithConfigFiles()
+		analysistest.Run(t, testdata, analyzer, "policyoverrides/strict")
+	})
+
+	t.Run("flag-driven analyzer ignores policy files", func(t *testing.T) {
+		// Test NewAnalyzer leaving the .nolintguard.yml file alone
+		analyzer := nolintguard.NewAnalyzer()
+		if analyzer.Flags.Lookup("config") != nil {
+			t.Fatal("NewAnalyzer defines a -config flag")
+		}
+		// The want comments expect the policy from the file, so they are not checked
+		results := analysistest.Run(&errorRecorder{}, testdata, analyzer, "policy")
+		for _, result := range results {
+			for _, diagnostic := range result.Diagnostics {
+				t.Errorf("unexpected diagnostic: %s", diagnostic.Message)
+			}
+		}
+	})
+
+	errorCases := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown key",
+			file:    ".nolintguard.yml",
+			content: "forbidden-linter:\n  - unused\n",
+			wantErr: `unknown field "forbidden-linter"`,
+		},
+		{
+			name:    "unknown key in JSON",
+			file:    ".nolintguard.json",
+			content: `{"require-justifications": true}`,
+			wantErr: `unknown field "require-justifications"`,
+		},
+		{
+			name:    "mistyped value",
+			file:    ".nolintguard.yml",
+			content: "forbidden-linters: staticcheck,unused\n",
+			wantErr: "forbidden-linters",
+		},
+		{
+			name:    "invalid policy",
+			file:    ".nolintguard.yml",
+			content: "forbidden-linters: [errcheck]\nallowed-linters: [errcheck]\n",
+			wantErr: "errcheck is listed in both forbidden and allowed linters",
+		},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tc.file)
+			if err := os.WriteFile(path, []byte(tc.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			analyzer := nolintguard.NewAnalyzerWithConfigFiles()
+			if err := analyzer.Flags.Set("config", path); err != nil {
+				t.Fatal(err)
+			}
+
+			recorder := &errorRecorder{}
+			analysistest.Run(recorder, testdata, analyzer, "c")
+			if !strings.Contains(recorder.String(), tc.wantErr) || !strings.Contains(recorder.String(), path) {
+				t.Fatalf("errors %q do not mention %q in %s", recorder, tc.wantErr, path)
+			}
+		})
+	}
+}
+
+// errorRecorder collects the errors reported by analysistest.
+type errorRecorder struct {
+	strings.Builder
+}
+
+func (r *errorRecorder) Errorf(format string, args ...any) {
+	fmt.Fprintf(r, format+"\n", args...)
+}
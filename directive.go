@@ -0,0 +1,276 @@
+package nolintguard
+
+import (
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// directive is a single suppression directive found in a comment. A comment may
+// hold several directives: stacked after an inline // or on separate lines of a
+// /* */ block.
+type directive struct {
+	// pos is the position of the directive in the file.
+	pos token.Pos
+
+	// raw is the directive as written, without comment markers or explanation.
+	raw string
+
+	// text is the directive text used for policy checks. It equals raw unless
+	// the directive is obfuscated, in which case it is the normalized form.
+	text string
+
+	// explanation is the text following the directive after an inline //, up to
+	// the end of the line. As in golangci-lint, it may include stacked directives.
+	explanation string
+
+	// obfuscated reports whether the directive only matches once case variants,
+	// confusable characters and invisible code points are normalized.
+	obfuscated bool
+
+	// tag is the nosec tag the directive starts with: #nosec or the alternative
+	// tag configured with NosecTag. It is empty for other directives.
+	tag string
+}
+
+// stackedDirectiveRe matches a well-formed directive. The text following an inline
+// // is only treated as a stacked directive when it matches, so explanations that
+// merely mention directives (e.g. "// see //nolint:gosec docs") are left alone.
+var stackedDirectiveRe = regexp.MustCompile(`^(?:` +
+	`nolint(?::\s*[\w-]*(?:\s*,\s*[\w-]*)*)?` +
+	`|#nosec(?:[\s,]+G\d+)*(?:\s+--.*)?` +
+	`|gosec:(?:disable|enable)(?:[\s,]+G\d+)*(?:\s+--.*)?` +
+	`|revive:(?:disable|enable)(?:-line|-next-line)?(?::[\w,-]+)?(?:\s.*)?` +
+	`)\s*$`)
+
+// golangciNolintRe is the pattern golangci-lint matches against the text of a
+// comment once leading slashes and spaces are trimmed.
+var golangciNolintRe = regexp.MustCompile(`^nolint( |:|$)`)
+
+// honoredByGolangci reports whether golangci-lint recognizes the //nolint directive
+// d in comment. golangci-lint only looks at the start of each comment, after
+// trimming slashes and spaces: directives in /* */ comments, after a tab, after an
+// inline // or in another letter case are ignored. A space after // is accepted.
+func honoredByGolangci(comment *ast.Comment, d directive) bool {
+	return d.pos == comment.Pos() && golangciNolintRe.MatchString(strings.TrimLeft(comment.Text, "/ "))
+}
+
+// nolintLinterList returns the linter list of the nolint directive text, i.e. the
+// text after the colon, and whether there is one. Without a colon, the directive
+// is a bare //nolint suppressing every linter, whatever text follows nolint.
+func nolintLinterList(text string) (string, bool) {
+	return strings.CutPrefix(strings.TrimSpace(strings.TrimPrefix(text, "nolint")), ":")
+}
+
+// canonicalNolint returns the canonical //nolint form of a nolint directive text,
+// e.g. //nolint:errcheck,gosec for "nolint: errcheck, gosec". Linters named after
+// a blank instead of a colon, as in "nolint gosec", form the list too when they
+// are all known to golangci-lint or listed in config.CustomLinters; other text
+// there is free-form and yields a bare //nolint.
+func canonicalNolint(text string, config Config) string {
+	list, ok := nolintLinterList(text)
+	if !ok {
+		list = strings.Join(strings.FieldsFunc(strings.TrimPrefix(text, "nolint"), func(r rune) bool {
+			return r == ',' || unicode.IsSpace(r)
+		}), ",")
+	}
+
+	linters := parseLinters(list)
+	for i, linter := range linters {
+		linters[i] = strings.ToLower(linter)
+	}
+
+	if len(linters) == 0 || (!ok && slices.ContainsFunc(linters, func(linter string) bool { return !isKnownLinter(linter, config) })) {
+		return "//nolint"
+	}
+
+	return "//nolint:" + strings.Join(linters, ",")
+}
+
+// commentLine is a line of a comment without comment markers.
+type commentLine struct {
+	// base is the position of the first byte of text.
+	base token.Pos
+
+	// head is the position reported for a directive starting the line.
+	head token.Pos
+
+	text string
+}
+
+// commentPiece is a part of a comment line delimited by //.
+type commentPiece struct {
+	// pos is the position reported for a directive starting the piece.
+	pos token.Pos
+
+	// base is the position of the first byte of text.
+	base token.Pos
+
+	text string
+}
+
+// parseDirectives returns every directive in comment, in source order. Each line
+// of the comment is split at inline // markers; the first piece of a line is
+// recognized leniently, later pieces only when they form a well-formed directive.
+// Nosec tags elsewhere in the remaining text are directives as well (see findNosecTags).
+func parseDirectives(comment *ast.Comment, nosecTag string) []directive {
+	var directives []directive
+
+	for _, line := range commentLines(comment) {
+		pieces := splitPieces(line)
+		for i, piece := range pieces {
+			rest := restOfLine(pieces[i+1:])
+
+			text, keyword, obfuscated := normalizeDirective(piece.text)
+			if keyword == "" || (i > 0 && !stackedDirectiveRe.MatchString(text)) {
+				directives = append(directives, findNosecTags(piece, i == 0, rest, nosecTag)...)
+				continue
+			}
+
+			d := directive{
+				pos:         piece.pos,
+				raw:         strings.Trim(piece.text, " \t"),
+				text:        text,
+				explanation: strings.TrimSpace(rest),
+				obfuscated:  obfuscated,
+			}
+			if keyword == "#nosec" {
+				d.tag = keyword
+			}
+			directives = append(directives, d)
+		}
+	}
+
+	return directives
+}
+
+// findNosecTags returns a directive for every nosec tag (#nosec or nosecTag) in a
+// piece that does not start with a recognized directive. gosec honours a tag
+// starting a comment line, and versions before v2.9 honoured it anywhere in a
+// comment, so a tag elsewhere counts as well when it is a word of its own
+// (e.g. "// legacy code #nosec G401 temporary", but not "#nosecurity").
+func findNosecTags(piece commentPiece, lineStart bool, rest, nosecTag string) []directive {
+	var directives []directive
+
+	for offset := 0; offset < len(piece.text); {
+		idx, tag := indexNosecTag(piece.text[offset:], nosecTag)
+		if idx == -1 {
+			break
+		}
+		start := offset + idx
+		offset = start + len(tag)
+
+		raw := strings.TrimRight(piece.text[start:], " \t")
+		atStart := strings.TrimSpace(piece.text[:start]) == ""
+		if !(atStart && lineStart) && !isNosecWord(piece.text, start, tag) {
+			continue
+		}
+
+		pos := piece.base + token.Pos(start)
+		if atStart {
+			pos = piece.pos
+		}
+
+		directives = append(directives, directive{
+			pos:         pos,
+			raw:         raw,
+			text:        raw,
+			explanation: strings.TrimSpace(rest),
+			tag:         tag,
+		})
+	}
+
+	return directives
+}
+
+// isNosecWord reports whether the tag at start in text is a word of its own, not
+// glued to the text before or after it as in "#nosecurity".
+func isNosecWord(text string, start int, tag string) bool {
+	before, _ := utf8.DecodeLastRuneInString(text[:start])
+	after, _ := utf8.DecodeRuneInString(text[start+len(tag):])
+
+	return !isWordRune(before) && !isWordRune(after)
+}
+
+// isWordRune reports whether r can be part of a word.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// indexNosecTag returns the index of the first #nosec or nosecTag in text and the
+// tag found there, or -1 if text holds neither. Like gosec, the match is a plain
+// prefix match, so #nosecG101 is a nosec tag too.
+func indexNosecTag(text, nosecTag string) (int, string) {
+	idx, tag := strings.Index(text, "#nosec"), "#nosec"
+	if nosecTag != "" {
+		if alt := strings.Index(text, nosecTag); alt != -1 && (idx == -1 || alt < idx) {
+			idx, tag = alt, nosecTag
+		}
+	}
+
+	return idx, tag
+}
+
+// restOfLine joins the pieces following a directive back into the text after the
+// directive's inline //.
+func restOfLine(pieces []commentPiece) string {
+	if len(pieces) == 0 {
+		return ""
+	}
+
+	texts := make([]string, 0, len(pieces))
+	for _, p := range pieces {
+		texts = append(texts, p.text)
+	}
+
+	return strings.Join(texts, "//")
+}
+
+// commentLines returns the lines of comment without comment markers. A directive
+// starting the first line is reported at the comment itself; one starting a later
+// line of a /* */ block at the line's first non-blank character.
+func commentLines(comment *ast.Comment) []commentLine {
+	text := comment.Text
+	base := comment.Pos() + token.Pos(len("//"))
+
+	if !strings.HasPrefix(text, "/*") {
+		return []commentLine{{base: base, head: comment.Pos(), text: text[len("//"):]}}
+	}
+
+	body := strings.TrimSuffix(text[len("/*"):], "*/")
+
+	var lines []commentLine
+	for i, line := range strings.Split(body, "\n") {
+		head := comment.Pos()
+		if i > 0 {
+			head = base + token.Pos(len(line)-len(strings.TrimLeft(line, " \t\r")))
+		}
+		lines = append(lines, commentLine{base: base, head: head, text: line})
+		base += token.Pos(len(line) + len("\n"))
+	}
+
+	return lines
+}
+
+// splitPieces splits a comment line at every inline //. The first piece is
+// positioned at the line head, later pieces at their // marker.
+func splitPieces(line commentLine) []commentPiece {
+	parts := strings.Split(line.text, "//")
+	pieces := make([]commentPiece, 0, len(parts))
+
+	offset := 0
+	for i, part := range parts {
+		pos, base := line.head, line.base+token.Pos(offset)
+		if i > 0 {
+			pos = base - token.Pos(len("//"))
+		}
+		pieces = append(pieces, commentPiece{pos: pos, base: base, text: part})
+		offset += len(part) + len("//")
+	}
+
+	return pieces
+}
@@ -0,0 +1,308 @@
+package nolintguard
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// gosecFix builds a suggested fix that rewrites a //nolint directive listing gosec
+// into a #nosec directive. The trailing explanation becomes the #nosec justification.
+// Other linters stay in a residual //nolint directive; since gosec reads comments
+// through ast.CommentGroup.Text, which drops //nolint: lines, the #nosec directive
+// then goes on its own comment line, below the residual directive when it is on
+// its own line and directly above the affected line otherwise, e.g.
+//
+//	//nolint:gosec,errcheck // reason  ->  //nolint:errcheck // reason
+//	                                       // #nosec -- reason
+//
+// No fix is offered for block comments keeping other linters or when the source
+// line cannot be read.
+func gosecFix(src *fileSource, comment *ast.Comment, linters []string, explanation string) (analysis.SuggestedFix, bool) {
+	nosec := "#nosec"
+	if explanation != "" {
+		nosec += " -- " + explanation
+	}
+
+	message := "Replace //nolint:gosec with #nosec"
+	residual := withoutLinter(linters, "gosec")
+	if len(residual) == 0 {
+		return analysis.SuggestedFix{
+			Message:   message,
+			TextEdits: []analysis.TextEdit{replaceComment(comment, nosec, true)},
+		}, true
+	}
+
+	if strings.HasPrefix(comment.Text, "/*") {
+		return analysis.SuggestedFix{}, false
+	}
+
+	prefix, ok := src.linePrefix(comment)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	nolint := "nolint:" + strings.Join(residual, ",")
+	if explanation != "" {
+		nolint += " // " + explanation
+	}
+
+	indent := prefix[:len(prefix)-len(strings.TrimLeft(prefix, " \t"))]
+
+	var edits []analysis.TextEdit
+	if strings.TrimSpace(prefix) != "" {
+		lineStart := src.file.LineStart(src.file.Line(comment.Pos()))
+		edits = []analysis.TextEdit{
+			{
+				Pos:     lineStart,
+				End:     lineStart,
+				NewText: []byte(indent + "// " + nosec + "\n"),
+			},
+			replaceComment(comment, nolint, false),
+		}
+	} else {
+		edits = []analysis.TextEdit{replaceComment(comment, nolint+"\n"+indent+"// "+nosec, false)}
+	}
+
+	return analysis.SuggestedFix{Message: message, TextEdits: edits}, true
+}
+
+// reviveFix builds a suggested fix that rewrites a //nolint directive listing revive
+// into a native revive directive. A trailing comment becomes //revive:disable-line,
+// a comment on its own line becomes //revive:disable-next-line, and the explanation
+// moves into the revive justification. Other linters stay in a residual //nolint
+// directive; since revive only honours directives that start a comment, the revive
+// directive then goes on its own line directly above the affected line.
+//
+// No fix is offered for block comments, for a comment on its own line followed by
+// more comments in its group, where //revive:disable-next-line would cover the
+// next comment instead of the code, or when the source line cannot be read.
+func reviveFix(src *fileSource, group *ast.CommentGroup, comment *ast.Comment, linters []string, explanation string) (analysis.SuggestedFix, bool) {
+	if strings.HasPrefix(comment.Text, "/*") {
+		return analysis.SuggestedFix{}, false
+	}
+
+	prefix, ok := src.linePrefix(comment)
+	if !ok {
+		return analysis.SuggestedFix{}, false
+	}
+
+	justification := ""
+	if explanation != "" {
+		justification = " " + explanation
+	}
+
+	trailing := strings.TrimSpace(prefix) != ""
+	if !trailing && comment != group.List[len(group.List)-1] {
+		return analysis.SuggestedFix{}, false
+	}
+
+	indent := prefix[:len(prefix)-len(strings.TrimLeft(prefix, " \t"))]
+	residual := withoutLinter(linters, "revive")
+
+	var edits []analysis.TextEdit
+	switch {
+	case len(residual) == 0 && trailing:
+		edits = []analysis.TextEdit{replaceComment(comment, "revive:disable-line"+justification, false)}
+	case len(residual) == 0:
+		edits = []analysis.TextEdit{replaceComment(comment, "revive:disable-next-line"+justification, false)}
+	case trailing:
+		lineStart := src.file.LineStart(src.file.Line(comment.Pos()))
+		edits = []analysis.TextEdit{
+			{
+				Pos:     lineStart,
+				End:     lineStart,
+				NewText: []byte(indent + "//revive:disable-next-line" + justification + "\n"),
+			},
+			replaceComment(comment, "nolint:"+strings.Join(residual, ","), false),
+		}
+	default:
+		body := "nolint:" + strings.Join(residual, ",") + "\n" + indent + "//revive:disable-next-line" + justification
+		edits = []analysis.TextEdit{replaceComment(comment, body, false)}
+	}
+
+	return analysis.SuggestedFix{
+		Message:   "Replace //nolint:revive with a native revive directive",
+		TextEdits: edits,
+	}, true
+}
+
+// fileSource gives the fixes for the directives in a file access to its source,
+// which is read on first use, once per file.
+type fileSource struct {
+	pass *analysis.Pass
+	file *token.File
+
+	content []byte
+	loaded  bool
+}
+
+// newFileSource returns the source of file.
+func newFileSource(pass *analysis.Pass, file *ast.File) *fileSource {
+	return &fileSource{pass: pass, file: pass.Fset.File(file.Pos())}
+}
+
+// load reads the file on first use and reports whether its content is available
+// and matches the parsed file.
+func (s *fileSource) load() bool {
+	if !s.loaded {
+		s.loaded = true
+		if s.file == nil {
+			return false
+		}
+
+		readFile := s.pass.ReadFile
+		if readFile == nil {
+			readFile = os.ReadFile
+		}
+
+		content, err := readFile(s.file.Name())
+		if err == nil && len(content) == s.file.Size() {
+			s.content = content
+		}
+	}
+
+	return s.content != nil
+}
+
+// linePrefix returns the source text between the start of the comment's line and
+// the comment itself. A prefix containing anything but whitespace means the comment
+// shares its line with code.
+func (s *fileSource) linePrefix(comment *ast.Comment) (string, bool) {
+	if !s.load() {
+		return "", false
+	}
+
+	start := s.file.Offset(s.file.LineStart(s.file.Line(comment.Pos())))
+	end := s.file.Offset(comment.Pos())
+
+	return string(s.content[start:end]), true
+}
+
+// lineSuffix returns the source text between the end of comment and the end of
+// its line.
+func (s *fileSource) lineSuffix(comment *ast.Comment) (string, bool) {
+	if !s.load() {
+		return "", false
+	}
+
+	rest := s.content[s.file.Offset(comment.End()):]
+	if idx := bytes.IndexByte(rest, '\n'); idx != -1 {
+		rest = rest[:idx]
+	}
+
+	return string(rest), true
+}
+
+// canonicalNolintFix builds a suggested fix rewriting a comment holding a single
+// //nolint directive that golangci-lint does not recognize into the canonical
+// //nolint:linter form, keeping the explanation. No fix is offered for a /* */
+// comment followed by code, which a // comment would swallow.
+func canonicalNolintFix(src *fileSource, comment *ast.Comment, canonical, explanation string) (analysis.SuggestedFix, bool) {
+	if strings.HasPrefix(comment.Text, "/*") {
+		suffix, ok := src.lineSuffix(comment)
+		if !ok || strings.TrimSpace(suffix) != "" {
+			return analysis.SuggestedFix{}, false
+		}
+	}
+
+	text := canonical
+	if explanation != "" {
+		text += " // " + explanation
+	}
+
+	return analysis.SuggestedFix{
+		Message: "Rewrite as " + canonical,
+		TextEdits: []analysis.TextEdit{{
+			Pos:     comment.Pos(),
+			End:     comment.End(),
+			NewText: []byte(text),
+		}},
+	}, true
+}
+
+// renameLintersFix builds a suggested fix that replaces deprecated linter names in a
+// //nolint directive with their canonical names, leaving the rest of the comment as is.
+// No fix is offered when the names cannot be located verbatim, e.g. in an
+// obfuscated directive.
+func renameLintersFix(comment *ast.Comment, renames map[string]string) (analysis.SuggestedFix, bool) {
+	text := comment.Text
+
+	// Locate the linter list: between the ':' following "nolint" and the
+	// explanation or the end of the comment
+	start := strings.Index(text, "nolint")
+	if start == -1 {
+		return analysis.SuggestedFix{}, false
+	}
+	start += len("nolint")
+	colon := strings.Index(text[start:], ":")
+	if colon == -1 {
+		return analysis.SuggestedFix{}, false
+	}
+	start += colon + 1
+	end := len(text)
+	if strings.HasPrefix(text, "/*") {
+		end -= len("*/")
+	}
+	if idx := strings.Index(text[start:end], "//"); idx != -1 {
+		end = start + idx
+	}
+
+	renamed := false
+	parts := strings.Split(text[start:end], ",")
+	for i, part := range parts {
+		name := strings.TrimSpace(part)
+		if canonical, ok := renames[name]; ok {
+			parts[i] = strings.Replace(part, name, canonical, 1)
+			renamed = true
+		}
+	}
+	if !renamed {
+		return analysis.SuggestedFix{}, false
+	}
+
+	return analysis.SuggestedFix{
+		Message: "Replace deprecated linter names",
+		TextEdits: []analysis.TextEdit{{
+			Pos:     comment.Pos() + token.Pos(start),
+			End:     comment.Pos() + token.Pos(end),
+			NewText: []byte(strings.Join(parts, ",")),
+		}},
+	}, true
+}
+
+// withoutLinter returns linters with every occurrence of name removed.
+func withoutLinter(linters []string, name string) []string {
+	residual := make([]string, 0, len(linters))
+	for _, linter := range linters {
+		if linter != name {
+			residual = append(residual, linter)
+		}
+	}
+
+	return residual
+}
+
+// replaceComment returns an edit replacing comment with body, preserving the
+// comment style. A space is inserted after // when spaced is true.
+func replaceComment(comment *ast.Comment, body string, spaced bool) analysis.TextEdit {
+	var text string
+	switch {
+	case strings.HasPrefix(comment.Text, "/*"):
+		text = "/* " + body + " */"
+	case spaced:
+		text = "// " + body
+	default:
+		text = "//" + body
+	}
+
+	return analysis.TextEdit{
+		Pos:     comment.Pos(),
+		End:     comment.End(),
+		NewText: []byte(text),
+	}
+}
@@ -2,7 +2,11 @@ module github.com/go-extras/nolintguard
 
 go 1.25.0
 
-require golang.org/x/tools v0.46.0
+require (
+	github.com/golangci/plugin-module-register v0.1.2
+	go.yaml.in/yaml/v3 v3.0.5
+	golang.org/x/tools v0.46.0
+)
 
 require (
 	golang.org/x/mod v0.37.0 // indirect
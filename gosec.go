@@ -0,0 +1,245 @@
+package nolintguard
+
+import (
+	"go/ast"
+	"go/token"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+var (
+	// gosecRuleIDRe matches a well-formed gosec rule ID.
+	gosecRuleIDRe = regexp.MustCompile(`^G\d{3}$`)
+
+	// gosecRuleLikeRe matches tokens that look like an attempt at a rule ID,
+	// such as g401, G40 or G4011.
+	gosecRuleLikeRe = regexp.MustCompile(`(?i)^g\d+$`)
+
+	// gosecEmbeddedRuleRe finds rule IDs glued to other characters, such as
+	// G401;G501 or (G401).
+	gosecEmbeddedRuleRe = regexp.MustCompile(`G\d{3}`)
+)
+
+// checkGosecRules validates the rule list of a #nosec or //gosec: directive.
+// name is the directive as shown in messages (e.g. #nosec or //gosec:disable),
+// rules the text between the directive keyword and the -- justification marker.
+// Blanket suppressions are reported when disable is true, i.e. for directives
+// that turn rules off.
+func checkGosecRules(pass *analysis.Pass, pos token.Pos, name, rules string, disable bool) {
+	fields := strings.FieldsFunc(rules, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+
+	var listed int
+	for _, field := range fields {
+		switch {
+		case field == "block":
+			// Legacy keyword suppressing every rule
+			continue
+		case gosecRuleIDRe.MatchString(field):
+			listed++
+			if _, found := slices.BinarySearch(knownGosecRules, field); !found {
+				pass.Reportf(pos, "nolintguard: %s names unknown gosec rule %s", name, field)
+			}
+		case gosecRuleLikeRe.MatchString(field):
+			listed++
+			pass.Reportf(pos, "nolintguard: %s rule ID %q is malformed; gosec rule IDs have the form G123", name, field)
+		default:
+			// Anything else is either a rule ID glued to punctuation or text that
+			// was meant as the justification; the rest of the list is not checked
+			pass.Reportf(pos, "nolintguard: %s has a malformed separator at %q; separate rule IDs with spaces or commas and the justification with --", name, field)
+			if gosecEmbeddedRuleRe.MatchString(field) {
+				listed++
+			}
+			return
+		}
+	}
+
+	if disable && listed == 0 {
+		pass.Reportf(pos, "nolintguard: %s without rule IDs suppresses every gosec rule; list the suppressed rules (e.g., %s G401)", name, name)
+	}
+}
+
+// checkForbiddenGosecRules reports #nosec and //gosec:disable directives that
+// suppress a rule from forbidden. Like gosec, every G123 sequence in the rule list
+// counts as a suppressed rule. Blanket suppressions are checked against the code
+// they are attached to (see checkBlanketGosecSuppression).
+func checkForbiddenGosecRules(pass *analysis.Pass, pos token.Pos, name, rules string, forbidden map[string]bool) {
+	ids := gosecEmbeddedRuleRe.FindAllString(rules, -1)
+	slices.Sort(ids)
+	for _, id := range slices.Compact(ids) {
+		if forbidden[id] {
+			pass.Reportf(pos, "nolintguard: %s must not suppress gosec rule %s", name, id)
+		}
+	}
+}
+
+// checkBlanketGosecSuppression reports a #nosec or //gosec:disable directive
+// without rule IDs attached to code that looks like it triggers a forbidden rule.
+// Only the rules in gosecRuleHeuristics can be recognized from the syntax tree;
+// require-gosec-rule-ids reports every blanket suppression.
+func checkBlanketGosecSuppression(pass *analysis.Pass, spans *suppressedSpans, group *ast.CommentGroup, d directive, config Config) {
+	var name, rules string
+	switch {
+	case d.tag != "":
+		name, rules = d.tag, gosecRuleList(d.text, d.tag)
+	case strings.HasPrefix(d.text, "gosec:disable"):
+		name, rules = "//gosec:disable", gosecRuleList(d.text, "gosec:disable")
+	default:
+		return
+	}
+	if gosecEmbeddedRuleRe.MatchString(rules) {
+		return
+	}
+
+	var triggered []string
+	for _, rule := range slices.Sorted(maps.Keys(config.ForbiddenGosecRules)) {
+		if likely, ok := gosecRuleHeuristics[rule]; ok && slices.ContainsFunc(spans.gosecNodes(group), func(node ast.Node) bool { return containsNode(node, likely) }) {
+			triggered = append(triggered, rule)
+		}
+	}
+
+	if len(triggered) > 0 {
+		pass.Reportf(d.pos, "nolintguard: %s without rule IDs also suppresses forbidden gosec rules %s", name, strings.Join(triggered, ", "))
+	}
+}
+
+// gosecRuleHeuristics maps the gosec rules recognizable from the syntax tree alone
+// to a check reporting whether a node looks like it triggers the rule.
+var gosecRuleHeuristics = map[string]func(ast.Node) bool{
+	"G101": isHardcodedCredential,
+	"G201": isSQLFormatString,
+	"G202": isSQLConcatenation,
+	"G402": isTLSConfig,
+}
+
+var (
+	// gosecCredentialNameRe is the pattern gosec matches against the names
+	// hard-coded credentials are assigned to.
+	gosecCredentialNameRe = regexp.MustCompile(`(?i)passwd|pass|password|pwd|secret|token|pw|apiKey|bearer|cred`)
+
+	// sqlQueryMethods are the methods of database/sql types taking a query.
+	sqlQueryMethods = []string{"Exec", "ExecContext", "Prepare", "PrepareContext", "Query", "QueryContext", "QueryRow", "QueryRowContext"}
+)
+
+// containsNode reports whether node or any node below it satisfies match.
+func containsNode(node ast.Node, match func(ast.Node) bool) bool {
+	var found bool
+	ast.Inspect(node, func(n ast.Node) bool {
+		found = found || (n != nil && match(n))
+		return !found
+	})
+
+	return found
+}
+
+// isHardcodedCredential reports whether n assigns a string literal to a
+// credential-like name (G101).
+func isHardcodedCredential(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		for i, lhs := range n.Lhs {
+			if i < len(n.Rhs) && isCredentialName(lhs) && isStringLiteral(n.Rhs[i]) {
+				return true
+			}
+		}
+	case *ast.ValueSpec:
+		for i, name := range n.Names {
+			if i < len(n.Values) && isCredentialName(name) && isStringLiteral(n.Values[i]) {
+				return true
+			}
+		}
+	case *ast.KeyValueExpr:
+		return isCredentialName(n.Key) && isStringLiteral(n.Value)
+	}
+
+	return false
+}
+
+// isCredentialName reports whether expr is an identifier or field selector with
+// a credential-like name.
+func isCredentialName(expr ast.Expr) bool {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return gosecCredentialNameRe.MatchString(expr.Name)
+	case *ast.SelectorExpr:
+		return gosecCredentialNameRe.MatchString(expr.Sel.Name)
+	}
+
+	return false
+}
+
+// isStringLiteral reports whether expr is a non-empty string literal.
+func isStringLiteral(expr ast.Expr) bool {
+	lit, ok := expr.(*ast.BasicLit)
+
+	return ok && lit.Kind == token.STRING && len(lit.Value) > len(`""`)
+}
+
+// isSQLFormatString reports whether n is a query call with an fmt.Sprintf
+// argument (G201).
+func isSQLFormatString(n ast.Node) bool {
+	return isSQLCall(n, func(arg ast.Expr) bool {
+		call, ok := arg.(*ast.CallExpr)
+		return ok && isSelector(call.Fun, "fmt", "Sprintf")
+	})
+}
+
+// isSQLConcatenation reports whether n is a query call with a string
+// concatenation argument (G202).
+func isSQLConcatenation(n ast.Node) bool {
+	return isSQLCall(n, func(arg ast.Expr) bool {
+		binary, ok := arg.(*ast.BinaryExpr)
+		return ok && binary.Op == token.ADD
+	})
+}
+
+// isSQLCall reports whether n is a call to a method in sqlQueryMethods with an
+// argument satisfying match.
+func isSQLCall(n ast.Node, match func(ast.Expr) bool) bool {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || !slices.Contains(sqlQueryMethods, sel.Sel.Name) {
+		return false
+	}
+
+	return slices.ContainsFunc(call.Args, match)
+}
+
+// isTLSConfig reports whether n is a tls.Config literal or an InsecureSkipVerify
+// field (G402).
+func isTLSConfig(n ast.Node) bool {
+	switch n := n.(type) {
+	case *ast.CompositeLit:
+		return isSelector(n.Type, "tls", "Config")
+	case *ast.Ident:
+		return n.Name == "InsecureSkipVerify"
+	}
+
+	return false
+}
+
+// isSelector reports whether expr is the selector pkg.name.
+func isSelector(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	x, ok := sel.X.(*ast.Ident)
+
+	return ok && x.Name == pkg
+}
+
+// gosecRuleList returns the rule list of a #nosec or //gosec: directive: the text
+// after the directive keyword up to the -- justification marker.
+func gosecRuleList(text, keyword string) string {
+	rules, _, _ := strings.Cut(strings.TrimPrefix(text, keyword), "--")
+
+	return rules
+}
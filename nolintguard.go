@@ -5,126 +5,434 @@
 //   - Forbidden usage of //nolint:gosec (requires #nosec or //gosec: directives instead)
 //   - Forbidden usage of //nolint:revive (requires native revive directives)
 //   - Optional restriction of specific linters via forbidden-linters configuration
-//   - Optional justification requirements for security/style suppression directives
+//   - Optional allowlist of the only linters that may be suppressed via allowed-linters
+//   - Optional justification requirements for security/style suppression directives,
+//     including #nosec tags anywhere in a comment and gosec's alternative nosec tag
+//   - Optional requirement for #nosec and //gosec:disable to list known gosec rule IDs
+//   - Optional list of gosec rules that must never be suppressed
+//   - Optional validation of //revive: rule names and a list of rules that must never be disabled
+//   - Optional checks for unbalanced //revive:disable and //revive:enable regions
+//   - Optional limits on the number of lines a single directive may suppress
+//   - Optional ban on directives that suppress a linter for the whole file
+//   - Optional checks for //nolint directives attached to no code or to too much code
+//   - Optional golangci-lint compatibility mode reporting //nolint forms it ignores
+//   - Optional bans on bare //nolint, empty //nolint: lists and //nolint:all
+//   - Directives obfuscated with case variants, confusable or invisible characters
+//   - Directives stacked after an inline // or on later lines of a /* */ block
+//   - Optional validation of linter names against the golangci-lint registry
+//   - Optional explanation requirements for //nolint directives, globally or per linter
+//   - Optional skipping of generated files, or reporting of any suppression in them
+//   - Optional checks of files excluded by build constraints
+//   - Per-path overrides of any of the above, selected by glob patterns on the file path,
+//     and a separate policy for test files
 //
-// This linter is designed to be used as a custom linter for golangci-lint.
+// The standalone command also reads its policy from a .nolintguard.yml or
+// .nolintguard.json file, found by walking up from each package directory
+// (see NewAnalyzerWithConfigFiles).
+//
+// This linter is designed to be used as a custom linter for golangci-lint,
+// either as a module plugin (see New) or through the standalone command.
 package nolintguard
 
 import (
+	"flag"
+	"fmt"
 	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"os"
+	"slices"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
 )
 
-// NewAnalyzer creates a new instance of the nolintguard analyzer.
+// NewAnalyzer creates a new instance of the nolintguard analyzer whose
+// configuration is driven by command-line flags.
 // This function is useful for testing with different flag configurations.
 func NewAnalyzer() *analysis.Analyzer {
-	var (
-		requireJustification bool
-		forbiddenLinters     string // comma-separated list
-	)
-
-	a := &analysis.Analyzer{
-		Name:             "nolintguard",
-		Doc:              "enforces project policy for nolint directives",
-		Run:              makeRun(&requireJustification, &forbiddenLinters),
-		RunDespiteErrors: true,
-	}
+	config := newFlagConfig()
+	a := newAnalyzer(flagResolver(config))
+	registerFlags(&a.Flags, config)
 
-	a.Flags.BoolVar(&requireJustification, "require-justification", false, "require security suppression directives (#nosec, //gosec:, //revive:) to include justification")
-	a.Flags.StringVar(&forbiddenLinters, "forbidden-linters", "", "comma-separated list of forbidden nolint linters (e.g., 'staticcheck,unused')")
+	return a
+}
+
+// NewAnalyzerWithConfigFiles creates a new instance of the nolintguard analyzer
+// configured like NewAnalyzer, which additionally reads the policy from the
+// nearest policy file (.nolintguard.yml or .nolintguard.json) above each package
+// directory, or from the file named by its -config flag. Flags set explicitly
+// take precedence over the file. The standalone command uses this analyzer.
+func NewAnalyzerWithConfigFiles() *analysis.Analyzer {
+	config := newFlagConfig()
+	files := newConfigFiles(config)
+	a := newAnalyzer(files.config)
+
+	registerFlags(&a.Flags, config)
+	files.trackFlags(&a.Flags)
+	a.Flags.StringVar(&files.path, "config", "", "path to a "+configFileNames[0]+" or "+configFileNames[2]+" policy file (default: the nearest one found walking up from each package directory)")
 
 	return a
 }
 
+// flagResolver returns a resolver for the configuration bound to the analyzer
+// flags. Flags are set after the analyzer is created but before analysis starts,
+// so the configuration is validated once, on the first pass.
+func flagResolver(config *Config) func(*analysis.Pass) (Config, error) {
+	validate := sync.OnceValue(func() error { return config.validate() })
+
+	return func(*analysis.Pass) (Config, error) {
+		return *config, validate()
+	}
+}
+
+// newFlagConfig returns an empty configuration for flags to be bound to.
+func newFlagConfig() *Config {
+	return &Config{
+		ForbiddenLinters: make(map[string]bool),
+		AllowedLinters:   make(map[string]bool),
+
+		CustomLinters:               make(map[string]bool),
+		RequireNolintExplanationFor: make(map[string]bool),
+		ForbiddenGosecRules:         make(map[string]bool),
+		ForbiddenReviveRules:        make(map[string]bool),
+		FileLevelAllowedLinters:     make(map[string]bool),
+	}
+}
+
+// registerFlags defines the flags configuring config on fs.
+func registerFlags(fs *flag.FlagSet, config *Config) {
+	fs.BoolVar(&config.RequireJustification, "require-justification", false, "require security suppression directives (#nosec, //gosec:, //revive:) to include justification")
+	fs.Var(linterSetFlag{set: config.ForbiddenLinters, canonical: true}, "forbidden-linters", "comma-separated list of forbidden nolint linters (e.g., 'staticcheck,unused')")
+	fs.Var(linterSetFlag{set: config.AllowedLinters, canonical: true}, "allowed-linters", "comma-separated list of the only nolint linters that may be used (e.g., 'lll,errcheck,funlen')")
+	fs.BoolVar(&config.ForbidBareNolint, "forbid-bare-nolint", false, "forbid //nolint directives without a linter list")
+	fs.BoolVar(&config.ForbidEmptyNolint, "forbid-empty-nolint", false, "forbid //nolint: directives with an empty linter list")
+	fs.BoolVar(&config.ForbidNolintAll, "forbid-nolint-all", false, "forbid //nolint:all directives")
+	fs.BoolVar(&config.ValidateLinterNames, "validate-linter-names", false, "report //nolint linter names unknown to golangci-lint")
+	fs.Var(linterSetFlag{set: config.CustomLinters}, "custom-linters", "comma-separated list of additional linter names accepted by -validate-linter-names (e.g., private plugins)")
+	fs.BoolVar(&config.RequireNolintExplanation, "require-nolint-explanation", false, "require every //nolint directive to include an explanation (//nolint:linter // reason)")
+	fs.BoolVar(&config.RequireGosecRuleIDs, "require-gosec-rule-ids", false, "require #nosec and //gosec:disable directives to list known gosec rule IDs (e.g., '#nosec G401')")
+	fs.Var(linterSetFlag{set: config.ForbiddenGosecRules}, "forbidden-gosec-rules", "comma-separated list of gosec rule IDs that #nosec and //gosec:disable must never suppress (e.g., 'G101,G402')")
+	fs.BoolVar(&config.ValidateReviveRules, "validate-revive-rules", false, "report //revive: rule names unknown to revive")
+	fs.Var(linterSetFlag{set: config.ForbiddenReviveRules}, "forbidden-revive-rules", "comma-separated list of revive rules that //revive:disable must never disable (e.g., 'exported')")
+	fs.BoolVar(&config.CheckReviveRegions, "check-revive-regions", false, "report unbalanced //revive:disable and //revive:enable directives")
+	fs.IntVar(&config.MaxNolintLines, "max-nolint-lines", 0, "maximum number of lines a //nolint directive may suppress (0 means no limit)")
+	fs.IntVar(&config.MaxNosecLines, "max-nosec-lines", 0, "maximum number of lines a #nosec or //gosec: directive may suppress (0 means no limit)")
+	fs.IntVar(&config.MaxReviveLines, "max-revive-lines", 0, "maximum number of lines a //revive:disable region may span (0 means no limit)")
+	fs.BoolVar(&config.ForbidFileLevelSuppressions, "forbid-file-level-suppressions", false, "forbid directives that suppress a linter for the whole file")
+	fs.Var(linterSetFlag{set: config.FileLevelAllowedLinters, canonical: true}, "file-level-allowed-linters", "comma-separated list of linters that may still be suppressed for the whole file (e.g., 'lll,revive')")
+	fs.BoolVar(&config.CheckNolintPlacement, "check-nolint-placement", false, "report //nolint directives attached to no code or to a whole compound statement")
+	fs.BoolVar(&config.GolangciCompat, "golangci-compat", false, "report //nolint directives golangci-lint does not recognize as ineffective instead of applying the policy to them")
+	fs.BoolVar(&config.ScanIgnoredFiles, "scan-ignored-files", false, "also check files excluded by build constraints (e.g. //go:build windows)")
+	fs.StringVar(&config.GeneratedFiles, "generated-files", GeneratedFilesCheck, "how to handle generated files: 'check' applies the policy, 'skip' ignores them and 'report' reports every suppression in them")
+	fs.StringVar(&config.NosecTag, "nosec-tag", "", "alternative tag that gosec honours in place of #nosec, as set with gosec's -nosec-tag (e.g., '#falsepositive')")
+	fs.Var(linterSetFlag{set: config.RequireNolintExplanationFor, canonical: true}, "require-nolint-explanation-for", "comma-separated list of linters whose //nolint directives must include an explanation (e.g., 'errcheck,staticcheck')")
+}
+
+// NewAnalyzerWithConfig creates a new instance of the nolintguard analyzer
+// configured from config. The configuration is validated and copied once here,
+// so later changes to config do not affect the returned analyzer.
+func NewAnalyzerWithConfig(config Config) (*analysis.Analyzer, error) {
+	normalized, err := config.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	return newAnalyzer(func(*analysis.Pass) (Config, error) {
+		return normalized, nil
+	}), nil
+}
+
 // Analyzer is the nolintguard analyzer that enforces project policy
 // for nolint directives.
 var Analyzer = NewAnalyzer()
 
-// Config holds the configuration options for the nolintguard analyzer.
-type Config struct {
-	// RequireJustification, when true, requires security suppression directives
-	// (#nosec, //gosec:, //revive:) to include a justification comment.
-	RequireJustification bool
-
-	// ForbiddenLinters is a map of linter names that should be forbidden
-	// in //nolint directives (e.g., staticcheck, unused).
-	ForbiddenLinters map[string]bool
+// newAnalyzer creates the analyzer shell shared by NewAnalyzer,
+// NewAnalyzerWithConfigFiles and NewAnalyzerWithConfig. The run function resolves
+// the configuration on every pass, which lets flags registered on the analyzer
+// update it after construction; resolve returns it validated.
+func newAnalyzer(resolve func(*analysis.Pass) (Config, error)) *analysis.Analyzer {
+	return &analysis.Analyzer{
+		Name:             analyzerName,
+		Doc:              analyzerDoc,
+		Run:              makeRun(resolve),
+		RunDespiteErrors: true,
+	}
 }
 
+const (
+	analyzerName = "nolintguard"
+	analyzerDoc  = "enforces project policy for nolint directives"
+)
+
 const (
 	gosecMessage             = "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
 	reviveMessage            = "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
-	nosecNoJustificationMsg  = "nolintguard: #nosec directive must include justification (-- reason)"
 	gosecNoJustificationMsg  = "nolintguard: //gosec: directive must include justification (-- reason)"
 	reviveNoJustificationMsg = "nolintguard: //revive: directive must include justification (reason)"
+	nolintNoExplanationMsg   = "nolintguard: //nolint directive must include explanation (// reason)"
+	bareNolintMsg            = "nolintguard: bare //nolint is forbidden; list the suppressed linters (//nolint:linter)"
+	emptyNolintMsg           = "nolintguard: //nolint: with an empty linter list is forbidden"
+	nolintAllMsg             = "nolintguard: //nolint:all is forbidden; list the suppressed linters"
 )
 
-// makeRun creates a run function with closure over flag pointers.
+// makeRun creates a run function with closure over the analyzer configuration.
 // This allows each analyzer instance to have its own configuration.
-func makeRun(requireJustification *bool, forbiddenLinters *string) func(*analysis.Pass) (any, error) {
+func makeRun(resolve func(*analysis.Pass) (Config, error)) func(*analysis.Pass) (any, error) {
 	return func(pass *analysis.Pass) (any, error) {
-		// Parse forbidden linters from comma-separated string
-		forbiddenMap := make(map[string]bool)
-		if *forbiddenLinters != "" {
-			for linter := range strings.SplitSeq(*forbiddenLinters, ",") {
-				linter = strings.TrimSpace(linter)
-				if linter != "" {
-					forbiddenMap[linter] = true
-				}
-			}
+		config, err := resolve(pass)
+		if err != nil {
+			return nil, err
 		}
 
-		config := Config{
-			RequireJustification: *requireJustification,
-			ForbiddenLinters:     forbiddenMap,
+		files := pass.Files
+		if config.ScanIgnoredFiles {
+			files = append(slices.Clip(files), parseIgnoredFiles(pass)...)
 		}
 
-		for _, file := range pass.Files {
-			inspectComments(pass, file, config)
+		for _, file := range files {
+			if err := checkFile(pass, file, config); err != nil {
+				return nil, err
+			}
 		}
 
 		return nil, nil
 	}
 }
 
+// checkFile applies the policy to file, according to its kind and path.
+func checkFile(pass *analysis.Pass, file *ast.File, config Config) error {
+	if ast.IsGenerated(file) {
+		switch config.GeneratedFiles {
+		case GeneratedFilesSkip:
+			return nil
+		case GeneratedFilesReport:
+			reportGeneratedSuppressions(pass, file, config)
+			return nil
+		}
+	}
+
+	filename := pass.Fset.File(file.Pos()).Name()
+	fileConfig, err := config.forFile(config.overridePath(pass, file, filename), isTestFile(filename, file))
+	if err != nil {
+		return err
+	}
+	inspectComments(pass, file, fileConfig)
+
+	return nil
+}
+
+// parseIgnoredFiles parses the Go files of the package excluded by build
+// constraints (e.g. //go:build windows or custom tags), so that directives in
+// them are checked too. The files are parsed in full, since directives are
+// checked against the code they are attached to, but without type checking;
+// files that cannot be read or have no package clause, such as templates
+// excluded with //go:build ignore, are skipped.
+func parseIgnoredFiles(pass *analysis.Pass) []*ast.File {
+	readFile := pass.ReadFile
+	if readFile == nil {
+		readFile = os.ReadFile
+	}
+
+	var files []*ast.File
+	for _, name := range pass.IgnoredFiles {
+		if !strings.HasSuffix(name, ".go") {
+			continue
+		}
+
+		content, err := readFile(name)
+		if err != nil {
+			continue
+		}
+
+		// A file with syntax errors still yields the comments and the code
+		// parsed before the first error, but without a package clause the
+		// parser returns an empty file that is not part of the file set
+		file, _ := parser.ParseFile(pass.Fset, name, content, parser.ParseComments|parser.SkipObjectResolution)
+		if file != nil && file.Package.IsValid() {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+// reportGeneratedSuppressions reports every suppression directive in the
+// generated file: unless the generator emits them, they are hand edits that the
+// next generation run silently drops. The policy is not applied to the file.
+func reportGeneratedSuppressions(pass *analysis.Pass, file *ast.File, config Config) {
+	for _, commentGroup := range file.Comments {
+		for _, comment := range commentGroup.List {
+			for _, d := range parseDirectives(comment, config.nosecTag()) {
+				if name, ok := suppressionName(d); ok {
+					pass.Reportf(d.pos, "nolintguard: %s in a generated file; generated code must not be edited by hand", name)
+				}
+			}
+		}
+	}
+}
+
+// suppressionName returns the name of the directive d in diagnostics, e.g.
+// //nolint:errcheck or #nosec, and whether d suppresses anything: //gosec:enable
+// and //revive:enable only lift earlier suppressions.
+func suppressionName(d directive) (string, bool) {
+	switch {
+	case d.tag != "":
+		return d.tag, true
+	case strings.HasPrefix(d.text, "gosec:"):
+		keyword := strings.Fields(d.text)[0]
+		return "//" + keyword, keyword != "gosec:enable"
+	case strings.HasPrefix(d.text, "revive:"):
+		head, disable, _, ok := parseReviveRules(d.text)
+		return "//" + head, ok && disable
+	default:
+		return "//" + d.text, true
+	}
+}
+
+// isTestFile reports whether file, named filename, is a test file: its name ends
+// in _test.go or it belongs to an external _test package.
+func isTestFile(filename string, file *ast.File) bool {
+	return strings.HasSuffix(filename, "_test.go") || strings.HasSuffix(file.Name.Name, "_test")
+}
+
 // inspectComments examines all comments in a file for nolint directive violations.
 func inspectComments(pass *analysis.Pass, file *ast.File, config Config) {
+	regions := newReviveRegions(config.CheckReviveRegions)
+	spans := &suppressedSpans{pass: pass, file: file}
+	src := newFileSource(pass, file)
+
 	for _, commentGroup := range file.Comments {
 		for _, comment := range commentGroup.List {
-			checkComment(pass, comment, config)
+			for _, d := range checkComment(pass, src, commentGroup, comment, config) {
+				checkSuppressedSpan(pass, spans, commentGroup, comment, d, config)
+				if len(config.ForbiddenGosecRules) > 0 {
+					checkBlanketGosecSuppression(pass, spans, commentGroup, d, config)
+				}
+				if config.ForbidFileLevelSuppressions {
+					checkFileLevelSuppression(pass, spans, commentGroup, comment, d, config)
+				}
+				if config.CheckNolintPlacement {
+					checkNolintPlacement(pass, spans, commentGroup, comment, d)
+				}
+
+				// revive only honours directives starting a // comment
+				if d.pos == comment.Pos() && !d.obfuscated && isLeadingReviveDirective(comment.Text) {
+					regions.add(pass, d.pos, d.text)
+				}
+			}
 		}
 	}
+
+	regions.finish(pass, file.End())
+	checkReviveSpans(pass, regions, config)
+	if config.ForbidFileLevelSuppressions {
+		checkFileLevelReviveRegions(pass, file, regions, config)
+	}
 }
 
-// checkComment analyzes a single comment for nolint directive violations.
-func checkComment(pass *analysis.Pass, comment *ast.Comment, config Config) {
-	text := comment.Text
+// isLeadingReviveDirective reports whether a comment starts with a revive directive
+// the way revive requires: a // comment whose text, after blanks, starts with revive:.
+func isLeadingReviveDirective(text string) bool {
+	after, ok := strings.CutPrefix(text, "//")
 
-	// Remove comment markers
-	text = strings.TrimPrefix(text, "//")
-	text = strings.TrimPrefix(text, "/*")
-	text = strings.TrimSuffix(text, "*/")
-	text = strings.TrimSpace(text)
+	return ok && strings.HasPrefix(strings.TrimLeft(after, " \t"), "revive:")
+}
 
-	// Strip any inline comment after the directive (e.g., "// want ..." in tests)
-	if idx := strings.Index(text, "//"); idx != -1 {
-		text = strings.TrimSpace(text[:idx])
+// checkComment analyzes a single comment for nolint directive violations and
+// returns the directives it found. Every directive in the comment is checked,
+// including directives stacked after an inline // and directives on separate
+// lines of a /* */ block.
+func checkComment(pass *analysis.Pass, src *fileSource, group *ast.CommentGroup, comment *ast.Comment, config Config) []directive {
+	directives := parseDirectives(comment, config.nosecTag())
+
+	// Suggested fixes rewrite the whole comment, so they are only offered
+	// for single-line comments holding a single directive
+	fixable := len(directives) == 1 && !strings.Contains(comment.Text, "\n")
+
+	checked := directives[:0:0]
+	for _, d := range directives {
+		if config.GolangciCompat && strings.HasPrefix(d.text, "nolint") && checkIneffectiveNolint(pass, src, comment, d, fixable, config) {
+			// golangci-lint ignores the directive, so it suppresses nothing
+			continue
+		}
+		if d.obfuscated {
+			pass.Reportf(d.pos, "nolintguard: obfuscated directive %+q normalizes to %q", d.raw, d.text)
+		}
+		checkDirective(pass, src, group, comment, d, fixable, config)
+		checked = append(checked, d)
 	}
 
-	// Check for #nosec directive
-	if strings.HasPrefix(text, "#nosec") {
+	return checked
+}
+
+// checkIneffectiveNolint reports a //nolint directive that golangci-lint does not
+// recognize, offering a fix to the canonical form, and reports whether it did.
+// A directive golangci-lint reads as a bare //nolint because of a space before the
+// linter list or in place of the colon is reported as well, but is still a
+// suppression.
+func checkIneffectiveNolint(pass *analysis.Pass, src *fileSource, comment *ast.Comment, d directive, fixable bool, config Config) bool {
+	canonical := canonicalNolint(d.text, config)
+
+	var message string
+	ineffective := !honoredByGolangci(comment, d)
+	switch {
+	case ineffective:
+		message = fmt.Sprintf("nolintguard: ineffective directive: golangci-lint does not recognize %q; use %s", d.raw, canonical)
+	case strings.HasPrefix(strings.TrimLeft(comment.Text, "/ "), "nolint ") && canonical != "//nolint":
+		message = fmt.Sprintf("nolintguard: golangci-lint reads %q as a bare //nolint suppressing every linter; use %s", d.raw, canonical)
+	default:
+		return false
+	}
+
+	diagnostic := analysis.Diagnostic{Pos: d.pos, Message: message}
+	if fixable {
+		if fix, ok := canonicalNolintFix(src, comment, canonical, d.explanation); ok {
+			diagnostic.SuggestedFixes = []analysis.SuggestedFix{fix}
+		}
+	}
+	pass.Report(diagnostic)
+
+	return ineffective
+}
+
+// checkDirective applies the policy to a single directive found in comment, a
+// comment of group.
+func checkDirective(pass *analysis.Pass, src *fileSource, group *ast.CommentGroup, comment *ast.Comment, d directive, fixable bool, config Config) {
+	text, explanation := d.text, d.explanation
+
+	// Check for #nosec directive or the alternative nosec tag
+	if d.tag != "" {
 		if config.RequireJustification {
-			checkNosecJustification(pass, comment, text)
+			checkNosecJustification(pass, d.pos, d.tag, text)
 		}
+		rules := gosecRuleList(text, d.tag)
+		if config.RequireGosecRuleIDs {
+			checkGosecRules(pass, d.pos, d.tag, rules, true)
+		}
+		checkForbiddenGosecRules(pass, d.pos, d.tag, rules, config.ForbiddenGosecRules)
 		return
 	}
 
 	// Check for //gosec: directive
 	if strings.HasPrefix(text, "gosec:") {
 		if config.RequireJustification {
-			checkGosecDirectiveJustification(pass, comment, text)
+			checkGosecDirectiveJustification(pass, d.pos, text)
+		}
+		for _, keyword := range []string{"gosec:disable", "gosec:enable"} {
+			if !strings.HasPrefix(text, keyword) {
+				continue
+			}
+			rules, disable := gosecRuleList(text, keyword), keyword == "gosec:disable"
+			if config.RequireGosecRuleIDs {
+				checkGosecRules(pass, d.pos, "//"+keyword, rules, disable)
+			}
+			if disable {
+				checkForbiddenGosecRules(pass, d.pos, "//"+keyword, rules, config.ForbiddenGosecRules)
+			}
+			break
 		}
 		return
 	}
@@ -132,8 +440,9 @@ func checkComment(pass *analysis.Pass, comment *ast.Comment, config Config) {
 	// Check for //revive: directive
 	if strings.HasPrefix(text, "revive:") {
 		if config.RequireJustification {
-			checkReviveJustification(pass, comment, text)
+			checkReviveJustification(pass, d.pos, text)
 		}
+		checkReviveRules(pass, d.pos, text, config)
 		return
 	}
 
@@ -142,37 +451,77 @@ func checkComment(pass *analysis.Pass, comment *ast.Comment, config Config) {
 		return
 	}
 
-	// Extract the part after "nolint"
-	remainder := strings.TrimPrefix(text, "nolint")
-
-	// Handle plain //nolint without arguments
-	if remainder == "" {
-		return // Plain nolint is allowed
+	// Handle plain //nolint without a linter list. Text after a blank, as in
+	// "//nolint legacy code", is no explanation: golangci-lint still reads the
+	// directive as a bare //nolint suppressing every linter
+	list, ok := nolintLinterList(text)
+	if !ok {
+		if config.ForbidBareNolint {
+			pass.Reportf(d.pos, "%s", bareNolintMsg)
+		}
+		checkNolintExplanation(pass, d.pos, nil, explanation, config)
+		return // Plain nolint is allowed unless forbidden
 	}
 
 	// Parse linter names from //nolint:linter1,linter2,...
-	// Skip any whitespace or colon
-	remainder = strings.TrimSpace(remainder)
-	if !strings.HasPrefix(remainder, ":") {
-		// Not a proper nolint directive format
-		return
+	rawLinters := parseLinters(list)
+
+	// Resolve deprecated and aliased names so they cannot bypass the policy
+	linters := checkDeprecatedLinters(pass, comment, d.pos, rawLinters, fixable)
+
+	if len(linters) == 0 && config.ForbidEmptyNolint {
+		pass.Reportf(d.pos, "%s", emptyNolintMsg)
 	}
 
-	linters := parseLinters(remainder[1:]) // Skip the ':'
+	checkNolintExplanation(pass, d.pos, linters, explanation, config)
 
 	// Check each linter for policy violations
+	gosecFixed, reviveFixed := !fixable, !fixable
 	for _, linter := range linters {
+		if linter == "all" && config.ForbidNolintAll {
+			pass.Reportf(d.pos, "%s", nolintAllMsg)
+			continue
+		}
+
+		// Unknown names suppress nothing, so the remaining checks do not apply
+		if config.ValidateLinterNames && !isKnownLinter(linter, config) {
+			reportUnknownLinter(pass, d.pos, linter, config)
+			continue
+		}
+
 		switch linter {
 		case "gosec":
 			// Always forbidden - must use #nosec
-			pass.Reportf(comment.Pos(), "%s", gosecMessage)
+			diagnostic := analysis.Diagnostic{Pos: d.pos, Message: gosecMessage}
+			if !gosecFixed {
+				// Duplicated entries share a single rewrite
+				if fix, ok := gosecFix(src, comment, linters, explanation); ok {
+					diagnostic.SuggestedFixes = []analysis.SuggestedFix{fix}
+				}
+				gosecFixed = true
+			}
+			pass.Report(diagnostic)
 		case "revive":
 			// Always forbidden - must use native revive directives
-			pass.Reportf(comment.Pos(), "%s", reviveMessage)
+			diagnostic := analysis.Diagnostic{Pos: d.pos, Message: reviveMessage}
+			// The gosec rewrite keeps revive in the residual //nolint directive,
+			// so only one fix is offered per comment to avoid conflicting edits
+			if !reviveFixed && !slices.Contains(linters, "gosec") {
+				if fix, ok := reviveFix(src, group, comment, linters, explanation); ok {
+					diagnostic.SuggestedFixes = []analysis.SuggestedFix{fix}
+				}
+				reviveFixed = true
+			}
+			pass.Report(diagnostic)
 		default:
 			// Check if this linter is in the forbidden list
 			if config.ForbiddenLinters[linter] {
-				pass.Reportf(comment.Pos(), "nolintguard: //nolint:%s is forbidden", linter)
+				pass.Reportf(d.pos, "nolintguard: //nolint:%s is forbidden", linter)
+				continue
+			}
+			// In allowlist mode every linter outside the list is reported
+			if len(config.AllowedLinters) > 0 && !config.AllowedLinters[linter] {
+				pass.Reportf(d.pos, "nolintguard: //nolint:%s is not in the allowed linters list", linter)
 			}
 		}
 	}
@@ -204,27 +553,99 @@ func parseLinters(lintersText string) []string {
 	return linters
 }
 
-// checkNosecJustification verifies that a #nosec directive includes a justification.
+// checkDeprecatedLinters reports deprecated or aliased linter names and returns
+// the linter list with every name replaced by its canonical form. The first report
+// carries a fix renaming all deprecated names in the directive, unless the gosec or
+// revive rewrite already replaces the whole directive.
+func checkDeprecatedLinters(pass *analysis.Pass, comment *ast.Comment, pos token.Pos, rawLinters []string, fixable bool) []string {
+	linters := make([]string, len(rawLinters))
+	renames := make(map[string]string)
+	for i, linter := range rawLinters {
+		canonical, deprecated := canonicalLinter(linter)
+		linters[i] = canonical
+		if deprecated {
+			renames[linter] = canonical
+		}
+	}
+
+	offerFix := fixable && !slices.Contains(linters, "gosec") && !slices.Contains(linters, "revive")
+	for _, linter := range rawLinters {
+		canonical, deprecated := renames[linter]
+		if !deprecated {
+			continue
+		}
+
+		diagnostic := analysis.Diagnostic{
+			Pos:     pos,
+			Message: fmt.Sprintf("nolintguard: //nolint:%s uses a deprecated linter name; use %s instead", linter, canonical),
+		}
+		if offerFix {
+			if fix, ok := renameLintersFix(comment, renames); ok {
+				diagnostic.SuggestedFixes = []analysis.SuggestedFix{fix}
+			}
+			offerFix = false
+		}
+		pass.Report(diagnostic)
+	}
+
+	return linters
+}
+
+// reportUnknownLinter reports a //nolint linter name that golangci-lint does not know,
+// suggesting the closest known or custom linter name when there is a plausible match.
+func reportUnknownLinter(pass *analysis.Pass, pos token.Pos, linter string, config Config) {
+	custom := slices.Sorted(maps.Keys(config.CustomLinters))
+	if suggestion := suggestName(linter, knownLinters, custom); suggestion != "" {
+		pass.Reportf(pos, "nolintguard: //nolint:%s names an unknown linter; did you mean %q?", linter, suggestion)
+		return
+	}
+
+	pass.Reportf(pos, "nolintguard: //nolint:%s names an unknown linter", linter)
+}
+
+// checkNolintExplanation verifies that a //nolint directive carries a golangci-lint
+// style explanation (//nolint:linter // explanation) when the configuration requires
+// one, either for every directive or for directives listing specific linters.
+func checkNolintExplanation(pass *analysis.Pass, pos token.Pos, linters []string, explanation string, config Config) {
+	if explanation != "" {
+		return
+	}
+
+	if config.RequireNolintExplanation {
+		pass.Reportf(pos, "%s", nolintNoExplanationMsg)
+		return
+	}
+
+	for _, linter := range linters {
+		if config.RequireNolintExplanationFor[linter] {
+			pass.Reportf(pos, "nolintguard: //nolint:%s directive must include explanation (// reason)", linter)
+			return
+		}
+	}
+}
+
+// checkNosecJustification verifies that a #nosec directive, or one using the
+// alternative nosec tag, includes a justification.
 // Format: #nosec [rules] -- justification.
-func checkNosecJustification(pass *analysis.Pass, comment *ast.Comment, text string) {
+func checkNosecJustification(pass *analysis.Pass, pos token.Pos, tag, text string) {
 	if !hasGosecJustification(text) {
-		pass.Reportf(comment.Pos(), "%s", nosecNoJustificationMsg)
+		pass.Reportf(pos, "nolintguard: %s directive must include justification (-- reason)", tag)
 	}
 }
 
 // checkGosecDirectiveJustification verifies that a //gosec: directive includes a justification.
 // Format: //gosec:disable [rules] -- justification.
-func checkGosecDirectiveJustification(pass *analysis.Pass, comment *ast.Comment, text string) {
+func checkGosecDirectiveJustification(pass *analysis.Pass, pos token.Pos, text string) {
 	if !hasGosecJustification(text) {
-		pass.Reportf(comment.Pos(), "%s", gosecNoJustificationMsg)
+		pass.Reportf(pos, "%s", gosecNoJustificationMsg)
 	}
 }
 
 // checkReviveJustification verifies that a //revive: directive includes a justification.
 // Format: //revive:disable justification (space-separated, not --).
-func checkReviveJustification(pass *analysis.Pass, comment *ast.Comment, text string) {
+func checkReviveJustification(pass *analysis.Pass, pos token.Pos, text string) {
 	if !hasReviveJustification(text) {
-		pass.Reportf(comment.Pos(), "%s", reviveNoJustificationMsg)
+		pass.Reportf(pos, "%s", reviveNoJustificationMsg)
 	}
 }
 
@@ -1,6 +1,9 @@
 package nolintguard_test
 
 import (
+	"fmt"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"golang.org/x/tools/go/analysis/analysistest"
@@ -47,6 +50,292 @@ func TestAnalyzer(t *testing.T) {
 		analysistest.Run(t, testdata, analyzer, "e")
 	})
 
+	t.Run("with allowed linters", func(t *testing.T) {
+		// Test allowlist mode
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("allowed-linters", "lll,errcheck,funlen")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "l")
+	})
+
+	t.Run("with nolint explanation required", func(t *testing.T) {
+		// Test explanation requirement for every //nolint directive
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("require-nolint-explanation", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "m")
+	})
+
+	t.Run("with nolint explanation required per linter", func(t *testing.T) {
+		// Test explanation requirement for specific linters
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("require-nolint-explanation-for", "errcheck,staticcheck")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "n")
+	})
+
+	t.Run("with broad nolint forms forbidden", func(t *testing.T) {
+		// Test bans on bare //nolint, empty //nolint: and //nolint:all
+		analyzer := nolintguard.NewAnalyzer()
+		for _, flag := range []string{"forbid-bare-nolint", "forbid-empty-nolint", "forbid-nolint-all"} {
+			if err := analyzer.Flags.Set(flag, "true"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		analysistest.Run(t, testdata, analyzer, "o")
+	})
+
+	t.Run("with linter name validation", func(t *testing.T) {
+		// Test unknown linter names with "did you mean" suggestions
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("validate-linter-names", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = analyzer.Flags.Set("custom-linters", "mycompanylint")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "p")
+	})
+
+	t.Run("obfuscated directives", func(t *testing.T) {
+		// Test case variants, confusable characters and invisible code points
+		analyzer := nolintguard.NewAnalyzer()
+		analysistest.Run(t, testdata, analyzer, "r")
+	})
+
+	t.Run("nosec tags anywhere in a comment", func(t *testing.T) {
+		// Test #nosec after other text and gosec's alternative nosec tag
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("require-justification", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = analyzer.Flags.Set("nosec-tag", "falsepositive")
+		if err != nil {
+			t.Fatal(err)
+		}
+		results := analysistest.Run(t, testdata, analyzer, "t")
+
+		// Tags after other text are reported at the tag itself
+		for _, result := range results {
+			for _, diagnostic := range result.Diagnostics {
+				posn := result.Pass.Fset.Position(diagnostic.Pos)
+				if posn.Line == 12 && posn.Column != 32 {
+					t.Errorf("%s: want column 32", posn)
+				}
+			}
+		}
+	})
+
+	t.Run("gosec rule IDs required", func(t *testing.T) {
+		// Test blanket suppressions, unknown and malformed rule IDs and separators
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("require-gosec-rule-ids", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "u")
+	})
+
+	t.Run("forbidden gosec rules", func(t *testing.T) {
+		// Test rules that must never be suppressed, whatever the justification
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("forbidden-gosec-rules", "G101,G201,G202,G402")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "v")
+	})
+
+	t.Run("revive rules", func(t *testing.T) {
+		// Test revive rule name validation and rules that must never be disabled
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("validate-revive-rules", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = analyzer.Flags.Set("forbidden-revive-rules", "exported")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "w")
+	})
+
+	t.Run("revive regions", func(t *testing.T) {
+		// Test unclosed regions, enables without a disable and redundant disables
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("check-revive-regions", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		results := analysistest.Run(t, testdata, analyzer, "x")
+
+		// Redundant disables point at the directive that opened the region
+		related := map[int]int{28: 24, 44: 35}
+		for _, result := range results {
+			for _, diagnostic := range result.Diagnostics {
+				line := result.Pass.Fset.Position(diagnostic.Pos).Line
+				want, ok := related[line]
+				if !ok {
+					continue
+				}
+				if len(diagnostic.Related) != 1 {
+					t.Fatalf("line %d: got %d related entries, want 1", line, len(diagnostic.Related))
+				}
+				if got := result.Pass.Fset.Position(diagnostic.Related[0].Pos).Line; got != want {
+					t.Errorf("line %d: related line = %d, want %d", line, got, want)
+				}
+				delete(related, line)
+			}
+		}
+		if len(related) != 0 {
+			t.Errorf("missing redundant disable diagnostics on lines %v", related)
+		}
+	})
+
+	t.Run("suppressed region size", func(t *testing.T) {
+		// Test line limits for nolint, nosec and revive directives
+		analyzer := nolintguard.NewAnalyzer()
+		for flag, value := range map[string]string{
+			"max-nolint-lines": "4",
+			"max-nosec-lines":  "3",
+			"max-revive-lines": "5",
+		} {
+			if err := analyzer.Flags.Set(flag, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+		analysistest.Run(t, testdata, analyzer, "y")
+	})
+
+	t.Run("file-level suppressions", func(t *testing.T) {
+		// Test directives whose scope is the whole file
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("forbid-file-level-suppressions", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = analyzer.Flags.Set("file-level-allowed-linters", "lll")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "z")
+	})
+
+	t.Run("nolint placement", func(t *testing.T) {
+		// Test directives attached to no code or to a whole compound statement
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("check-nolint-placement", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "placement")
+	})
+
+	t.Run("golangci-lint compatibility", func(t *testing.T) {
+		// Test directives golangci-lint ignores and their rewrite to the canonical form
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("golangci-compat", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "compat")
+	})
+
+	t.Run("generated files reported", func(t *testing.T) {
+		// Test reporting suppressions in generated files instead of checking them
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("generated-files", "report")
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "generated")
+	})
+
+	t.Run("generated files skipped", func(t *testing.T) {
+		// Test skipping generated files while checking the rest of the package
+		analyzer := nolintguard.NewAnalyzer()
+		for flag, value := range map[string]string{"generated-files": "skip", "require-justification": "true"} {
+			if err := analyzer.Flags.Set(flag, value); err != nil {
+				t.Fatal(err)
+			}
+		}
+		analysistest.Run(t, testdata, analyzer, "generatedskip")
+	})
+
+	t.Run("files excluded by build constraints", func(t *testing.T) {
+		// Test checking files excluded by //go:build windows and custom tags
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("scan-ignored-files", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		// analysistest does not read want comments from files excluded by build
+		// constraints and reports their diagnostics as unexpected, so every
+		// diagnostic is checked here instead
+		results := analysistest.Run(&errorRecorder{}, testdata, analyzer, "ignored")
+
+		want := map[string]string{
+			"ignored.go:9":          "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead",
+			"ignored_windows.go:11": "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead",
+			"integration.go:11":     "nolintguard: //nolint:revive is forbidden; use native revive directives instead",
+		}
+		for _, result := range results {
+			for _, diagnostic := range result.Diagnostics {
+				posn := result.Pass.Fset.Position(diagnostic.Pos)
+				key := fmt.Sprintf("%s:%d", filepath.Base(posn.Filename), posn.Line)
+				if message, ok := want[key]; !ok || message != diagnostic.Message {
+					t.Errorf("unexpected diagnostic at %s: %s", key, diagnostic.Message)
+				}
+				delete(want, key)
+			}
+		}
+		for key, message := range want {
+			t.Errorf("no diagnostic reported at %s: %s", key, message)
+		}
+	})
+
+	t.Run("stacked directives", func(t *testing.T) {
+		// Test directives hidden after an inline // or on later lines of a block comment
+		analyzer := nolintguard.NewAnalyzer()
+		err := analyzer.Flags.Set("require-justification", "true")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = analyzer.Flags.Set("forbidden-linters", "staticcheck")
+		if err != nil {
+			t.Fatal(err)
+		}
+		results := analysistest.Run(t, testdata, analyzer, "s")
+
+		// Each diagnostic points at the offending directive, not at the comment
+		want := map[string]int{
+			"s.go:9:20":  1, // //nolint:errcheck //nolint:gosec
+			"s.go:17:23": 1, // // #nosec G401 -- ok //nolint:revive
+			"s.go:58:5":  1, // second line of the block comment
+		}
+		for _, result := range results {
+			for _, diagnostic := range result.Diagnostics {
+				posn := result.Pass.Fset.Position(diagnostic.Pos)
+				key := fmt.Sprintf("%s:%d:%d", filepath.Base(posn.Filename), posn.Line, posn.Column)
+				want[key]--
+			}
+		}
+		for key, missing := range want {
+			if missing > 0 {
+				t.Errorf("no diagnostic reported at %s", key)
+			}
+		}
+	})
+
 	t.Run("edge cases", func(t *testing.T) {
 		// Test edge cases: duplicates, trailing commas, whitespace, etc.
 		analyzer := nolintguard.NewAnalyzer()
@@ -86,4 +375,281 @@ func TestAnalyzer(t *testing.T) {
 		}
 		analysistest.Run(t, testdata, analyzer, "i")
 	})
+
+	t.Run("gosec suggested fixes", func(t *testing.T) {
+		// Test rewriting //nolint:gosec into #nosec directives
+		analyzer := nolintguard.NewAnalyzer()
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "j")
+	})
+
+	t.Run("revive suggested fixes", func(t *testing.T) {
+		// Test rewriting //nolint:revive into native revive directives
+		analyzer := nolintguard.NewAnalyzer()
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "k")
+	})
+}
+
+func TestNewAnalyzerWithConfig(t *testing.T) {
+	testdata := analysistest.TestData()
+
+	t.Run("forbidden linters", func(t *testing.T) {
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			ForbiddenLinters: map[string]bool{"staticcheck": true, "unused": true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "e")
+	})
+
+	t.Run("combined options", func(t *testing.T) {
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			RequireJustification: true,
+			ForbiddenLinters:     map[string]bool{"staticcheck": true, "unused": true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "h")
+	})
+
+	t.Run("config is copied at construction", func(t *testing.T) {
+		forbidden := map[string]bool{"staticcheck": true, "unused": true}
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{ForbiddenLinters: forbidden})
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Mutating the caller's map must not leak into the analyzer.
+		delete(forbidden, "unused")
+		analysistest.Run(t, testdata, analyzer, "e")
+	})
+
+	t.Run("allowed linters", func(t *testing.T) {
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			AllowedLinters: map[string]bool{"lll": true, "errcheck": true, "funlen": true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "l")
+	})
+
+	t.Run("linter both forbidden and allowed", func(t *testing.T) {
+		_, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			ForbiddenLinters: map[string]bool{"errcheck": true},
+			AllowedLinters:   map[string]bool{"errcheck": true, "lll": true},
+		})
+		if err == nil || !strings.Contains(err.Error(), "errcheck is listed in both forbidden and allowed linters") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("gosec cannot be allowed", func(t *testing.T) {
+		_, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			AllowedLinters: map[string]bool{"gosec": true},
+		})
+		if err == nil || !strings.Contains(err.Error(), "gosec cannot be allowed") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("deprecated linter names", func(t *testing.T) {
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			ForbiddenLinters: map[string]bool{"staticcheck": true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "q")
+	})
+
+	t.Run("path overrides", func(t *testing.T) {
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			RequireJustification: true,
+			ForbiddenLinters:     map[string]bool{"staticcheck": true},
+			Overrides: []nolintguard.Override{
+				{
+					Paths:               []string{"overrides/internal/payments/**"},
+					ForbiddenLinters:    map[string]bool{"errcheck": true, "staticcheck": true},
+					RequireGosecRuleIDs: ptr(true),
+					ForbiddenGosecRules: map[string]bool{"G104": true},
+				},
+				{
+					Paths:                []string{"overrides/tools/**", "overrides/examples/**"},
+					ForbiddenLinters:     map[string]bool{},
+					RequireJustification: ptr(false),
+				},
+				{
+					// Later overrides take precedence for the fields they set
+					Paths:            []string{"overrides/internal/payments/legacy_*.go"},
+					ForbiddenLinters: map[string]bool{"staticcheck": true},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "overrides/internal/payments", "overrides/tools")
+	})
+
+	t.Run("conflicting path overrides", func(t *testing.T) {
+		// Test overrides that are valid on their own but conflict where both match
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			Overrides: []nolintguard.Override{
+				{
+					Paths:            []string{"overrides/internal/**"},
+					ForbiddenLinters: map[string]bool{"errcheck": true},
+				},
+				{
+					Paths:          []string{"overrides/internal/payments/legacy_*.go"},
+					AllowedLinters: map[string]bool{"errcheck": true},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		recorder := &errorRecorder{}
+		analysistest.Run(recorder, testdata, analyzer, "overrides/internal/payments")
+		want := "errcheck is listed in both forbidden and allowed linters (in Overrides[0], Overrides[1])"
+		if !strings.Contains(recorder.String(), want) {
+			t.Fatalf("errors %q do not mention %q", recorder, want)
+		}
+	})
+
+	t.Run("path overrides relative to the module root", func(t *testing.T) {
+		// Test patterns naming directories above the package path, such as the
+		// testdata and src directories the packages are checked out in
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			Overrides: []nolintguard.Override{
+				{
+					Paths:            []string{"src/**", "testdata/**", "payments/**"},
+					ForbiddenLinters: map[string]bool{"errcheck": true},
+				},
+				{
+					Paths:            []string{"/overrides/tools/**"},
+					ForbiddenLinters: map[string]bool{"staticcheck": true},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "overridesroot/...")
+	})
+
+	t.Run("test file policy", func(t *testing.T) {
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			ForbiddenLinters:    map[string]bool{"errcheck": true},
+			ForbiddenGosecRules: map[string]bool{"G404": true},
+			TestFiles: &nolintguard.Override{
+				ForbiddenLinters:    map[string]bool{"staticcheck": true},
+				ForbiddenGosecRules: map[string]bool{},
+			},
+			Overrides: []nolintguard.Override{
+				{
+					Paths:               []string{"testfiles/strict_test.go"},
+					ForbiddenGosecRules: map[string]bool{"G404": true},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.Run(t, testdata, analyzer, "testfiles")
+	})
+
+	t.Run("test file policy conflict", func(t *testing.T) {
+		_, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			ForbiddenLinters: map[string]bool{"errcheck": true},
+			TestFiles:        &nolintguard.Override{AllowedLinters: map[string]bool{"errcheck": true}},
+		})
+		if err == nil || !strings.Contains(err.Error(), "errcheck is listed in both forbidden and allowed linters (in TestFiles)") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	overrideErrors := []struct {
+		name     string
+		override nolintguard.Override
+		wantErr  string
+	}{
+		{name: "no paths", override: nolintguard.Override{RequireJustification: ptr(true)}, wantErr: "Overrides[0] has no Paths"},
+		{name: "malformed pattern", override: nolintguard.Override{Paths: []string{"internal/[payments/**"}}, wantErr: `malformed path pattern "internal/[payments/**"`},
+		{
+			name:     "conflict with base configuration",
+			override: nolintguard.Override{Paths: []string{"tools/**"}, AllowedLinters: map[string]bool{"errcheck": true}},
+			wantErr:  "errcheck is listed in both forbidden and allowed linters (in Overrides[0])",
+		},
+	}
+
+	for _, tc := range overrideErrors {
+		t.Run("override "+tc.name, func(t *testing.T) {
+			_, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+				ForbiddenLinters: map[string]bool{"errcheck": true},
+				Overrides:        []nolintguard.Override{tc.override},
+			})
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	t.Run("unknown generated files mode", func(t *testing.T) {
+		_, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{GeneratedFiles: "ignore"})
+		if err == nil || !strings.Contains(err.Error(), `GeneratedFiles "ignore" must be "check", "skip" or "report"`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("deprecated linter name in configuration", func(t *testing.T) {
+		analyzer, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			ForbiddenLinters: map[string]bool{"gosimple": true},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		analysistest.RunWithSuggestedFixes(t, testdata, analyzer, "q")
+	})
+
+	t.Run("invalid forbidden gosec rule", func(t *testing.T) {
+		_, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			ForbiddenGosecRules: map[string]bool{"g101": true},
+		})
+		if err == nil || !strings.Contains(err.Error(), `"g101" is not a gosec rule ID`) {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("negative line limit", func(t *testing.T) {
+		_, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			MaxNolintLines: -1,
+		})
+		if err == nil || !strings.Contains(err.Error(), "MaxNolintLines must not be negative") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("invalid nosec tag", func(t *testing.T) {
+		_, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			NosecTag: "#false positive",
+		})
+		if err == nil || !strings.Contains(err.Error(), "must be a single word") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("invalid linter name", func(t *testing.T) {
+		_, err := nolintguard.NewAnalyzerWithConfig(nolintguard.Config{
+			ForbiddenLinters: map[string]bool{"staticcheck,unused": true},
+		})
+		if err == nil {
+			t.Fatal("expected an error for a comma-separated linter name")
+		}
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
 }
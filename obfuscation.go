@@ -0,0 +1,149 @@
+package nolintguard
+
+import (
+	"strings"
+	"unicode"
+)
+
+// confusables maps letters from other scripts that render like the ASCII letters
+// used in directive keywords and linter names.
+var confusables = map[rune]rune{
+	// Cyrillic
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p', 'с': 'c',
+	'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w', 'ӏ': 'l',
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P', 'С': 'C',
+	'Т': 'T', 'У': 'Y', 'Х': 'X', 'І': 'I', 'Ј': 'J', 'Ѕ': 'S', 'Ӏ': 'I',
+	// Greek
+	'α': 'a', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'υ': 'u', 'χ': 'x',
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M', 'Ν': 'N',
+	'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	// Latin lookalikes
+	'ı': 'i', 'ɡ': 'g', 'ℓ': 'l',
+}
+
+// directiveKeywords are the directive prefixes recognized after normalization.
+// nolint is handled separately because it must be followed by ':' or nothing.
+var directiveKeywords = []string{"#nosec", "gosec:", "revive:"}
+
+// normalizeDirective classifies a piece of comment text. It returns the directive
+// keyword the text starts with once case variants, confusable characters and
+// invisible code points are normalized, or an empty keyword if the text is not a
+// directive. Text that only starts with a keyword once normalized must also be a
+// well-formed directive. For obfuscated directives the normalized text is
+// returned so the regular policy still applies to them; any other text is
+// returned trimmed but otherwise unchanged.
+func normalizeDirective(raw string) (text, keyword string, obfuscated bool) {
+	text = strings.TrimSpace(raw)
+
+	folded := strings.TrimSpace(foldDirective(raw))
+	lower := strings.ToLower(folded)
+
+	keyword = directiveKeyword(lower)
+	if keyword == "" {
+		return text, "", false
+	}
+
+	// Only the directive head is inspected: justifications may legitimately
+	// contain non-ASCII text and rule IDs are conventionally upper case
+	body := strings.TrimLeft(raw, " \t")
+	head := body
+	var caseVariant bool
+	switch keyword {
+	case "nolint":
+		head = strings.TrimRight(head, " \t")
+		// The text after a bare nolint is free-form
+		if _, listed := nolintLinterList(lower); !listed {
+			head = strings.Fields(head)[0]
+		}
+		caseVariant = head != strings.ToLower(head)
+	case "revive:":
+		if idx := strings.IndexAny(head, " \t"); idx != -1 {
+			head = head[:idx]
+		}
+		caseVariant = !strings.HasPrefix(body, keyword)
+	default:
+		head, _, _ = strings.Cut(head, "--")
+		caseVariant = !strings.HasPrefix(body, keyword)
+	}
+
+	if !caseVariant && !hasNonASCII(head) {
+		return text, keyword, false
+	}
+
+	normalized := keyword + folded[len(keyword):]
+	if keyword == "nolint" {
+		normalized = lower
+	}
+
+	// Prose that merely starts with a capitalized keyword, such as "Revive:
+	// restart the worker", is only an obfuscated directive if it is well-formed
+	if !stackedDirectiveRe.MatchString(normalized) {
+		return text, "", false
+	}
+
+	return normalized, keyword, true
+}
+
+// directiveKeyword returns the directive keyword that the lower-cased, normalized
+// text starts with, or an empty string if the text is not a directive.
+func directiveKeyword(text string) string {
+	if after, ok := strings.CutPrefix(text, "nolint"); ok {
+		// Like golangci-lint, nolint followed by a blank and other text is a
+		// directive too: a bare one, unless the text is a linter list
+		if after == "" || strings.HasPrefix(after, ":") || strings.TrimLeft(after, " \t") != after {
+			return "nolint"
+		}
+		return ""
+	}
+
+	for _, keyword := range directiveKeywords {
+		if strings.HasPrefix(text, keyword) {
+			return keyword
+		}
+	}
+
+	return ""
+}
+
+// foldDirective strips invisible code points, maps Unicode spaces to ASCII spaces
+// and maps confusable and full-width characters to their ASCII counterparts.
+// Letter case is preserved where an ASCII counterpart exists.
+func foldDirective(text string) string {
+	var b strings.Builder
+	b.Grow(len(text))
+
+	for _, r := range text {
+		switch {
+		case r <= unicode.MaxASCII:
+		case unicode.In(r, unicode.Cf, unicode.Mn):
+			// Zero-width spaces and joiners, bidi controls, combining marks
+			continue
+		case unicode.IsSpace(r):
+			r = ' '
+		case r >= '！' && r <= '～':
+			// Full-width forms of ASCII characters
+			r = r - '！' + '!'
+		default:
+			if ascii, ok := confusables[r]; ok {
+				r = ascii
+			} else if lower := unicode.ToLower(r); lower <= unicode.MaxASCII {
+				// e.g. KELVIN SIGN lower-cases to 'k'
+				r = lower
+			}
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// hasNonASCII reports whether s contains any non-ASCII rune.
+func hasNonASCII(s string) bool {
+	for _, r := range s {
+		if r > unicode.MaxASCII {
+			return true
+		}
+	}
+
+	return false
+}
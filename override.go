@@ -0,0 +1,330 @@
+package nolintguard
+
+import (
+	"fmt"
+	"go/ast"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Override adjusts the configuration for the files whose path matches one of
+// Paths. Nil fields leave the configuration unchanged; a non-nil set replaces the
+// set in the configuration, so an empty set clears it.
+//
+// Paths are glob patterns matched against the slash-separated path of the file
+// relative to the module root, or to the directory of the policy file the
+// configuration was read from, in path.Match syntax extended with ** for any
+// number of directories. A pattern matches the whole path, so internal/payments/**
+// matches every file below the top-level internal/payments directory only; a
+// leading / is allowed and makes no difference.
+type Override struct {
+	// Paths lists the glob patterns selecting the files the override applies to.
+	Paths []string
+
+	// RequireJustification, when set, replaces Config.RequireJustification.
+	RequireJustification *bool
+
+	// ForbiddenLinters, when non-nil, replaces Config.ForbiddenLinters.
+	ForbiddenLinters map[string]bool
+
+	// AllowedLinters, when non-nil, replaces Config.AllowedLinters; an empty set
+	// turns allowlist mode off.
+	AllowedLinters map[string]bool
+
+	// ForbidBareNolint, when set, replaces Config.ForbidBareNolint.
+	ForbidBareNolint *bool
+
+	// ForbidEmptyNolint, when set, replaces Config.ForbidEmptyNolint.
+	ForbidEmptyNolint *bool
+
+	// ForbidNolintAll, when set, replaces Config.ForbidNolintAll.
+	ForbidNolintAll *bool
+
+	// ValidateLinterNames, when set, replaces Config.ValidateLinterNames.
+	ValidateLinterNames *bool
+
+	// RequireNolintExplanation, when set, replaces Config.RequireNolintExplanation.
+	RequireNolintExplanation *bool
+
+	// RequireNolintExplanationFor, when non-nil, replaces
+	// Config.RequireNolintExplanationFor.
+	RequireNolintExplanationFor map[string]bool
+
+	// RequireGosecRuleIDs, when set, replaces Config.RequireGosecRuleIDs.
+	RequireGosecRuleIDs *bool
+
+	// ForbiddenGosecRules, when non-nil, replaces Config.ForbiddenGosecRules.
+	ForbiddenGosecRules map[string]bool
+
+	// ValidateReviveRules, when set, replaces Config.ValidateReviveRules.
+	ValidateReviveRules *bool
+
+	// ForbiddenReviveRules, when non-nil, replaces Config.ForbiddenReviveRules.
+	ForbiddenReviveRules map[string]bool
+
+	// CheckReviveRegions, when set, replaces Config.CheckReviveRegions.
+	CheckReviveRegions *bool
+
+	// MaxNolintLines, when set, replaces Config.MaxNolintLines; 0 removes the limit.
+	MaxNolintLines *int
+
+	// MaxNosecLines, when set, replaces Config.MaxNosecLines; 0 removes the limit.
+	MaxNosecLines *int
+
+	// MaxReviveLines, when set, replaces Config.MaxReviveLines; 0 removes the limit.
+	MaxReviveLines *int
+
+	// ForbidFileLevelSuppressions, when set, replaces
+	// Config.ForbidFileLevelSuppressions.
+	ForbidFileLevelSuppressions *bool
+
+	// FileLevelAllowedLinters, when non-nil, replaces Config.FileLevelAllowedLinters.
+	FileLevelAllowedLinters map[string]bool
+
+	// CheckNolintPlacement, when set, replaces Config.CheckNolintPlacement.
+	CheckNolintPlacement *bool
+}
+
+// forFile returns the configuration applying to the file at filename, the
+// slash-separated path returned by overridePath: c with TestFiles applied if test
+// is set and every matching override then applied in order, so that when several
+// overrides match, the later ones take precedence for the fields they set. Each
+// override was validated on its own; the configuration yielded by several of them
+// is validated the first time they match a file together.
+func (c Config) forFile(filename string, test bool) (Config, error) {
+	if len(c.Overrides) == 0 && c.TestFiles == nil {
+		return c, nil
+	}
+
+	overrides, testFiles := c.Overrides, c.TestFiles
+	c.Overrides, c.TestFiles = nil, nil
+
+	var applied []string
+	if test && testFiles != nil && (len(testFiles.Paths) == 0 || testFiles.matches(filename)) {
+		c = testFiles.apply(c)
+		applied = append(applied, "TestFiles")
+	}
+	for i, o := range overrides {
+		if o.matches(filename) {
+			c = o.apply(c)
+			applied = append(applied, fmt.Sprintf("Overrides[%d]", i))
+		}
+	}
+
+	if len(applied) < 2 {
+		return c, nil
+	}
+
+	return c, c.combinations.validate(strings.Join(applied, ", "), c)
+}
+
+// overrideCombinations caches the validation of the configurations yielded by
+// combinations of overrides, keyed by the overrides applied.
+type overrideCombinations struct {
+	mu   sync.Mutex
+	errs map[string]error
+}
+
+// newOverrideCombinations returns an empty cache.
+func newOverrideCombinations() *overrideCombinations {
+	return &overrideCombinations{errs: make(map[string]error)}
+}
+
+// validate validates config, yielded by the overrides listed in applied, unless
+// the same overrides were validated before. A nil cache validates every time.
+func (o *overrideCombinations) validate(applied string, config Config) error {
+	if o == nil {
+		return validateCombination(applied, config)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	err, ok := o.errs[applied]
+	if !ok {
+		err = validateCombination(applied, config)
+		o.errs[applied] = err
+	}
+
+	return err
+}
+
+// validateCombination validates config, yielded by the overrides listed in applied.
+func validateCombination(applied string, config Config) error {
+	if err := config.validate(); err != nil {
+		return fmt.Errorf("%w (in %s)", err, applied)
+	}
+
+	return nil
+}
+
+// overridePath returns the path override patterns are matched against for the
+// file at filename in the package analyzed by pass: the path relative to the
+// directory of the policy file, if the configuration was read from one holding
+// the file, or else relative to the module root, derived from the package path.
+// Patterns therefore never match the directories the module is checked out in.
+func (c Config) overridePath(pass *analysis.Pass, file *ast.File, filename string) string {
+	if c.policyDir != "" {
+		if abs, err := filepath.Abs(filename); err == nil {
+			if rel, err := filepath.Rel(c.policyDir, abs); err == nil && filepath.IsLocal(rel) {
+				return filepath.ToSlash(rel)
+			}
+		}
+	}
+
+	// An external test package has the path of the package it tests plus _test
+	dir := pass.Pkg.Path()
+	if strings.HasSuffix(file.Name.Name, "_test") {
+		dir = strings.TrimSuffix(dir, "_test")
+	}
+	if pass.Module != nil && pass.Module.Path != "" {
+		if dir == pass.Module.Path {
+			dir = ""
+		} else {
+			dir = strings.TrimPrefix(dir, pass.Module.Path+"/")
+		}
+	}
+
+	return path.Join(dir, filepath.Base(filename))
+}
+
+// matches reports whether one of the override's patterns matches filename.
+func (o Override) matches(filename string) bool {
+	for _, pattern := range o.Paths {
+		if matchPath(pattern, filename) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// apply returns c with the fields set in the override replaced.
+func (o Override) apply(c Config) Config {
+	setBool(&c.RequireJustification, o.RequireJustification)
+	setSet(&c.ForbiddenLinters, o.ForbiddenLinters)
+	setSet(&c.AllowedLinters, o.AllowedLinters)
+	setBool(&c.ForbidBareNolint, o.ForbidBareNolint)
+	setBool(&c.ForbidEmptyNolint, o.ForbidEmptyNolint)
+	setBool(&c.ForbidNolintAll, o.ForbidNolintAll)
+	setBool(&c.ValidateLinterNames, o.ValidateLinterNames)
+	setBool(&c.RequireNolintExplanation, o.RequireNolintExplanation)
+	setSet(&c.RequireNolintExplanationFor, o.RequireNolintExplanationFor)
+	setBool(&c.RequireGosecRuleIDs, o.RequireGosecRuleIDs)
+	setSet(&c.ForbiddenGosecRules, o.ForbiddenGosecRules)
+	setBool(&c.ValidateReviveRules, o.ValidateReviveRules)
+	setSet(&c.ForbiddenReviveRules, o.ForbiddenReviveRules)
+	setBool(&c.CheckReviveRegions, o.CheckReviveRegions)
+	setInt(&c.MaxNolintLines, o.MaxNolintLines)
+	setInt(&c.MaxNosecLines, o.MaxNosecLines)
+	setInt(&c.MaxReviveLines, o.MaxReviveLines)
+	setBool(&c.ForbidFileLevelSuppressions, o.ForbidFileLevelSuppressions)
+	setSet(&c.FileLevelAllowedLinters, o.FileLevelAllowedLinters)
+	setBool(&c.CheckNolintPlacement, o.CheckNolintPlacement)
+
+	return c
+}
+
+// copy returns a copy of the override that does not share any slices or maps
+// with the original. field names the override in error messages.
+func (o Override) copy(field string) (Override, error) {
+	o.Paths = slices.Clone(o.Paths)
+
+	for _, set := range []struct {
+		field   string
+		set     *map[string]bool
+		linters bool
+	}{
+		{"ForbiddenLinters", &o.ForbiddenLinters, true},
+		{"AllowedLinters", &o.AllowedLinters, true},
+		{"RequireNolintExplanationFor", &o.RequireNolintExplanationFor, true},
+		{"ForbiddenGosecRules", &o.ForbiddenGosecRules, false},
+		{"ForbiddenReviveRules", &o.ForbiddenReviveRules, false},
+		{"FileLevelAllowedLinters", &o.FileLevelAllowedLinters, true},
+	} {
+		if *set.set == nil {
+			continue
+		}
+		copied, err := copyLinterSet(field+"."+set.field, *set.set)
+		if err != nil {
+			return Override{}, err
+		}
+		if set.linters {
+			canonicalizeLinters(copied)
+		}
+		*set.set = copied
+	}
+
+	return o, nil
+}
+
+// setBool replaces *field with *value unless value is nil.
+func setBool(field *bool, value *bool) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// setInt replaces *field with *value unless value is nil.
+func setInt(field *int, value *int) {
+	if value != nil {
+		*field = *value
+	}
+}
+
+// setSet replaces *field with value unless value is nil.
+func setSet(field *map[string]bool, value map[string]bool) {
+	if value != nil {
+		*field = value
+	}
+}
+
+// validatePathPattern reports whether pattern is a valid override path pattern.
+func validatePathPattern(pattern string) error {
+	if strings.TrimSpace(pattern) == "" {
+		return fmt.Errorf("path pattern is empty")
+	}
+
+	for _, elem := range strings.Split(strings.TrimPrefix(pattern, "/"), "/") {
+		if _, err := path.Match(elem, ""); err != nil {
+			return fmt.Errorf("malformed path pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// matchPath reports whether the slash-separated relative filename matches pattern.
+func matchPath(pattern, filename string) bool {
+	return matchElems(strings.Split(strings.TrimPrefix(pattern, "/"), "/"), strings.Split(filename, "/"))
+}
+
+// matchElems matches path elements against pattern elements, where a **
+// element matches any number of path elements, including none.
+func matchElems(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := len(name); i >= 0; i-- {
+				if matchElems(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+
+	return len(name) == 0
+}
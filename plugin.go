@@ -0,0 +1,425 @@
+package nolintguard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis"
+)
+
+//nolint:gochecknoinits // golangci-lint module plugins must register themselves at import time
+func init() {
+	register.Plugin("nolintguard", New)
+}
+
+// Settings mirrors the golangci-lint configuration block
+// linters.settings.custom.nolintguard.settings.
+//
+// Example:
+//
+//	linters:
+//	  settings:
+//	    custom:
+//	      nolintguard:
+//	        type: module
+//	        settings:
+//	          require-justification: true
+//	          forbidden-linters:
+//	            - staticcheck
+//	            - unused
+type Settings struct {
+	// RequireJustification, when true, requires security suppression directives
+	// (#nosec, //gosec:, //revive:) to include a justification comment.
+	RequireJustification bool `json:"require-justification"`
+
+	// ForbiddenLinters lists linter names that should be forbidden
+	// in //nolint directives (e.g., staticcheck, unused).
+	ForbiddenLinters []string `json:"forbidden-linters"`
+
+	// AllowedLinters, when non-empty, lists the only linter names that may
+	// appear in //nolint directives.
+	AllowedLinters []string `json:"allowed-linters"`
+
+	// ForbidBareNolint, when true, reports //nolint directives without a linter list.
+	ForbidBareNolint bool `json:"forbid-bare-nolint"`
+
+	// ForbidEmptyNolint, when true, reports //nolint: directives whose linter list is empty.
+	ForbidEmptyNolint bool `json:"forbid-empty-nolint"`
+
+	// ForbidNolintAll, when true, reports //nolint:all directives.
+	ForbidNolintAll bool `json:"forbid-nolint-all"`
+
+	// ValidateLinterNames, when true, reports linter names in //nolint directives
+	// that are unknown to golangci-lint.
+	ValidateLinterNames bool `json:"validate-linter-names"`
+
+	// CustomLinters lists additional linter names accepted by validate-linter-names.
+	CustomLinters []string `json:"custom-linters"`
+
+	// RequireNolintExplanation, when true, requires every //nolint directive to
+	// include an explanation (//nolint:linter // reason).
+	RequireNolintExplanation bool `json:"require-nolint-explanation"`
+
+	// RequireNolintExplanationFor lists linters whose //nolint directives must
+	// include an explanation.
+	RequireNolintExplanationFor []string `json:"require-nolint-explanation-for"`
+
+	// RequireGosecRuleIDs, when true, requires #nosec and //gosec:disable directives
+	// to list known gosec rule IDs.
+	RequireGosecRuleIDs bool `json:"require-gosec-rule-ids"`
+
+	// ForbiddenGosecRules lists gosec rule IDs that #nosec and //gosec:disable
+	// directives must never suppress (e.g., G101, G402).
+	ForbiddenGosecRules []string `json:"forbidden-gosec-rules"`
+
+	// ValidateReviveRules, when true, reports //revive: rule names unknown to revive.
+	ValidateReviveRules bool `json:"validate-revive-rules"`
+
+	// ForbiddenReviveRules lists revive rules that //revive:disable directives
+	// must never disable (e.g., exported).
+	ForbiddenReviveRules []string `json:"forbidden-revive-rules"`
+
+	// CheckReviveRegions, when true, reports unbalanced //revive:disable and
+	// //revive:enable directives.
+	CheckReviveRegions bool `json:"check-revive-regions"`
+
+	// MaxNolintLines, when positive, limits the number of lines a //nolint
+	// directive may suppress.
+	MaxNolintLines int `json:"max-nolint-lines"`
+
+	// MaxNosecLines, when positive, limits the number of lines a #nosec or
+	// //gosec: directive may suppress.
+	MaxNosecLines int `json:"max-nosec-lines"`
+
+	// MaxReviveLines, when positive, limits the number of lines a
+	// //revive:disable region may span.
+	MaxReviveLines int `json:"max-revive-lines"`
+
+	// ForbidFileLevelSuppressions, when true, reports directives that suppress a
+	// linter for the whole file.
+	ForbidFileLevelSuppressions bool `json:"forbid-file-level-suppressions"`
+
+	// FileLevelAllowedLinters lists linters that may still be suppressed for the
+	// whole file.
+	FileLevelAllowedLinters []string `json:"file-level-allowed-linters"`
+
+	// CheckNolintPlacement, when true, reports //nolint directives attached to
+	// no code or to a whole compound statement.
+	CheckNolintPlacement bool `json:"check-nolint-placement"`
+
+	// GolangciCompat, when true, reports //nolint directives golangci-lint does
+	// not recognize as ineffective instead of applying the policy to them.
+	GolangciCompat bool `json:"golangci-compat"`
+
+	// NosecTag is an alternative tag that gosec honours in place of #nosec,
+	// matching gosec's -nosec-tag setting (e.g., #falsepositive).
+	NosecTag string `json:"nosec-tag"`
+
+	// GeneratedFiles selects how generated files are handled: check (default),
+	// skip or report.
+	GeneratedFiles string `json:"generated-files"`
+
+	// ScanIgnoredFiles, when true, also checks files excluded by build constraints.
+	ScanIgnoredFiles bool `json:"scan-ignored-files"`
+
+	// Overrides adjust the settings for files whose path matches a glob pattern.
+	// When several overrides match a file, the later ones take precedence.
+	Overrides []OverrideSettings `json:"overrides"`
+
+	// TestFiles adjusts the settings for test files (_test.go files and external
+	// _test packages). Path overrides are applied after it.
+	TestFiles *OverrideSettings `json:"test-files"`
+}
+
+// OverrideSettings mirrors an entry of the overrides list in the settings, or the
+// test-files block, whose paths are optional. Keys left out keep their value; a
+// list replaces the list it overrides.
+//
+// Example:
+//
+//	overrides:
+//	  - paths:
+//	      - internal/payments/**
+//	    forbidden-linters:
+//	      - errcheck
+//	    require-justification: true
+//	  - paths:
+//	      - tools/**
+//	      - examples/**
+//	    forbidden-linters: []
+type OverrideSettings struct {
+	// Paths lists the glob patterns selecting the files the override applies to.
+	Paths []string `json:"paths"`
+
+	// RequireJustification, when set, replaces require-justification.
+	RequireJustification *bool `json:"require-justification"`
+
+	// ForbiddenLinters, when present, replaces forbidden-linters.
+	ForbiddenLinters []string `json:"forbidden-linters"`
+
+	// AllowedLinters, when present, replaces allowed-linters; an empty list turns
+	// allowlist mode off.
+	AllowedLinters []string `json:"allowed-linters"`
+
+	// ForbidBareNolint, when set, replaces forbid-bare-nolint.
+	ForbidBareNolint *bool `json:"forbid-bare-nolint"`
+
+	// ForbidEmptyNolint, when set, replaces forbid-empty-nolint.
+	ForbidEmptyNolint *bool `json:"forbid-empty-nolint"`
+
+	// ForbidNolintAll, when set, replaces forbid-nolint-all.
+	ForbidNolintAll *bool `json:"forbid-nolint-all"`
+
+	// ValidateLinterNames, when set, replaces validate-linter-names.
+	ValidateLinterNames *bool `json:"validate-linter-names"`
+
+	// RequireNolintExplanation, when set, replaces require-nolint-explanation.
+	RequireNolintExplanation *bool `json:"require-nolint-explanation"`
+
+	// RequireNolintExplanationFor, when present, replaces
+	// require-nolint-explanation-for.
+	RequireNolintExplanationFor []string `json:"require-nolint-explanation-for"`
+
+	// RequireGosecRuleIDs, when set, replaces require-gosec-rule-ids.
+	RequireGosecRuleIDs *bool `json:"require-gosec-rule-ids"`
+
+	// ForbiddenGosecRules, when present, replaces forbidden-gosec-rules.
+	ForbiddenGosecRules []string `json:"forbidden-gosec-rules"`
+
+	// ValidateReviveRules, when set, replaces validate-revive-rules.
+	ValidateReviveRules *bool `json:"validate-revive-rules"`
+
+	// ForbiddenReviveRules, when present, replaces forbidden-revive-rules.
+	ForbiddenReviveRules []string `json:"forbidden-revive-rules"`
+
+	// CheckReviveRegions, when set, replaces check-revive-regions.
+	CheckReviveRegions *bool `json:"check-revive-regions"`
+
+	// MaxNolintLines, when set, replaces max-nolint-lines; 0 removes the limit.
+	MaxNolintLines *int `json:"max-nolint-lines"`
+
+	// MaxNosecLines, when set, replaces max-nosec-lines; 0 removes the limit.
+	MaxNosecLines *int `json:"max-nosec-lines"`
+
+	// MaxReviveLines, when set, replaces max-revive-lines; 0 removes the limit.
+	MaxReviveLines *int `json:"max-revive-lines"`
+
+	// ForbidFileLevelSuppressions, when set, replaces
+	// forbid-file-level-suppressions.
+	ForbidFileLevelSuppressions *bool `json:"forbid-file-level-suppressions"`
+
+	// FileLevelAllowedLinters, when present, replaces file-level-allowed-linters.
+	FileLevelAllowedLinters []string `json:"file-level-allowed-linters"`
+
+	// CheckNolintPlacement, when set, replaces check-nolint-placement.
+	CheckNolintPlacement *bool `json:"check-nolint-placement"`
+}
+
+// DecodeSettings converts the raw settings value handed over by golangci-lint
+// into a Settings value. Unknown keys and values of the wrong type are rejected.
+func DecodeSettings(rawSettings any) (Settings, error) {
+	settings, err := register.DecodeSettings[Settings](rawSettings)
+	if err != nil {
+		return Settings{}, fmt.Errorf("nolintguard: invalid settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Config validates the settings and converts them into a Config.
+func (s Settings) Config() (Config, error) {
+	forbidden, err := settingsLinterSet("forbidden-linters", s.ForbiddenLinters)
+	if err != nil {
+		return Config{}, err
+	}
+
+	allowed, err := settingsLinterSet("allowed-linters", s.AllowedLinters)
+	if err != nil {
+		return Config{}, err
+	}
+
+	custom, err := settingsLinterSet("custom-linters", s.CustomLinters)
+	if err != nil {
+		return Config{}, err
+	}
+
+	explanationFor, err := settingsLinterSet("require-nolint-explanation-for", s.RequireNolintExplanationFor)
+	if err != nil {
+		return Config{}, err
+	}
+
+	forbiddenRules, err := settingsLinterSet("forbidden-gosec-rules", s.ForbiddenGosecRules)
+	if err != nil {
+		return Config{}, err
+	}
+
+	forbiddenReviveRules, err := settingsLinterSet("forbidden-revive-rules", s.ForbiddenReviveRules)
+	if err != nil {
+		return Config{}, err
+	}
+
+	fileLevelAllowed, err := settingsLinterSet("file-level-allowed-linters", s.FileLevelAllowedLinters)
+	if err != nil {
+		return Config{}, err
+	}
+
+	// Directives are checked against canonical linter names, so deprecated
+	// names in the settings are resolved the same way
+	for _, set := range []map[string]bool{forbidden, allowed, explanationFor, fileLevelAllowed} {
+		canonicalizeLinters(set)
+	}
+
+	config := Config{
+		RequireJustification:        s.RequireJustification,
+		ForbiddenLinters:            forbidden,
+		AllowedLinters:              allowed,
+		ForbidBareNolint:            s.ForbidBareNolint,
+		ForbidEmptyNolint:           s.ForbidEmptyNolint,
+		ForbidNolintAll:             s.ForbidNolintAll,
+		ValidateLinterNames:         s.ValidateLinterNames,
+		CustomLinters:               custom,
+		RequireNolintExplanation:    s.RequireNolintExplanation,
+		RequireNolintExplanationFor: explanationFor,
+		RequireGosecRuleIDs:         s.RequireGosecRuleIDs,
+		ForbiddenGosecRules:         forbiddenRules,
+		ValidateReviveRules:         s.ValidateReviveRules,
+		ForbiddenReviveRules:        forbiddenReviveRules,
+		CheckReviveRegions:          s.CheckReviveRegions,
+		MaxNolintLines:              s.MaxNolintLines,
+		MaxNosecLines:               s.MaxNosecLines,
+		MaxReviveLines:              s.MaxReviveLines,
+		ForbidFileLevelSuppressions: s.ForbidFileLevelSuppressions,
+		FileLevelAllowedLinters:     fileLevelAllowed,
+		CheckNolintPlacement:        s.CheckNolintPlacement,
+		GolangciCompat:              s.GolangciCompat,
+		NosecTag:                    strings.TrimSpace(s.NosecTag),
+		GeneratedFiles:              strings.TrimSpace(s.GeneratedFiles),
+		ScanIgnoredFiles:            s.ScanIgnoredFiles,
+	}
+
+	for i, o := range s.Overrides {
+		override, err := o.override(fmt.Sprintf("overrides[%d]", i))
+		if err != nil {
+			return Config{}, err
+		}
+		config.Overrides = append(config.Overrides, override)
+	}
+
+	if s.TestFiles != nil {
+		testFiles, err := s.TestFiles.override("test-files")
+		if err != nil {
+			return Config{}, err
+		}
+		config.TestFiles = &testFiles
+	}
+
+	if err := config.validate(); err != nil {
+		return Config{}, err
+	}
+
+	return config, nil
+}
+
+// override validates the override settings and converts them into an Override.
+// key names the overrides entry in error messages.
+func (o OverrideSettings) override(key string) (Override, error) {
+	override := Override{
+		Paths:                       o.Paths,
+		RequireJustification:        o.RequireJustification,
+		ForbidBareNolint:            o.ForbidBareNolint,
+		ForbidEmptyNolint:           o.ForbidEmptyNolint,
+		ForbidNolintAll:             o.ForbidNolintAll,
+		ValidateLinterNames:         o.ValidateLinterNames,
+		RequireNolintExplanation:    o.RequireNolintExplanation,
+		RequireGosecRuleIDs:         o.RequireGosecRuleIDs,
+		ValidateReviveRules:         o.ValidateReviveRules,
+		CheckReviveRegions:          o.CheckReviveRegions,
+		MaxNolintLines:              o.MaxNolintLines,
+		MaxNosecLines:               o.MaxNosecLines,
+		MaxReviveLines:              o.MaxReviveLines,
+		ForbidFileLevelSuppressions: o.ForbidFileLevelSuppressions,
+		CheckNolintPlacement:        o.CheckNolintPlacement,
+	}
+
+	for _, set := range []struct {
+		key       string
+		linters   []string
+		set       *map[string]bool
+		canonical bool
+	}{
+		{"forbidden-linters", o.ForbiddenLinters, &override.ForbiddenLinters, true},
+		{"allowed-linters", o.AllowedLinters, &override.AllowedLinters, true},
+		{"require-nolint-explanation-for", o.RequireNolintExplanationFor, &override.RequireNolintExplanationFor, true},
+		{"forbidden-gosec-rules", o.ForbiddenGosecRules, &override.ForbiddenGosecRules, false},
+		{"forbidden-revive-rules", o.ForbiddenReviveRules, &override.ForbiddenReviveRules, false},
+		{"file-level-allowed-linters", o.FileLevelAllowedLinters, &override.FileLevelAllowedLinters, true},
+	} {
+		// A list left out keeps the list it would override
+		if set.linters == nil {
+			continue
+		}
+		linters, err := settingsLinterSet(key+"."+set.key, set.linters)
+		if err != nil {
+			return Override{}, err
+		}
+		if set.canonical {
+			canonicalizeLinters(linters)
+		}
+		*set.set = linters
+	}
+
+	return override, nil
+}
+
+// settingsLinterSet converts a list of linter names from the settings into a set.
+// key names the settings key in error messages.
+func settingsLinterSet(key string, linters []string) (map[string]bool, error) {
+	set := make(map[string]bool, len(linters))
+	for i, linter := range linters {
+		linter = strings.TrimSpace(linter)
+		if err := validateLinterName(linter); err != nil {
+			return nil, fmt.Errorf("nolintguard: invalid settings: %s[%d]: %w", key, i, err)
+		}
+		set[linter] = true
+	}
+
+	return set, nil
+}
+
+// New is the golangci-lint module plugin constructor. It receives the decoded
+// settings map from the golangci-lint configuration and returns a plugin that
+// builds the nolintguard analyzer from it.
+func New(rawSettings any) (register.LinterPlugin, error) {
+	settings, err := DecodeSettings(rawSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := settings.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	return &plugin{config: config}, nil
+}
+
+// plugin implements register.LinterPlugin.
+type plugin struct {
+	config Config
+}
+
+// BuildAnalyzers returns the nolintguard analyzer configured from the plugin settings.
+func (p *plugin) BuildAnalyzers() ([]*analysis.Analyzer, error) {
+	analyzer, err := NewAnalyzerWithConfig(p.config)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*analysis.Analyzer{analyzer}, nil
+}
+
+// GetLoadMode reports that nolintguard only needs the syntax tree.
+func (*plugin) GetLoadMode() string {
+	return register.LoadModeSyntax
+}
@@ -0,0 +1,245 @@
+package nolintguard_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golangci/plugin-module-register/register"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/go-extras/nolintguard"
+)
+
+func TestDecodeSettings(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		raw := map[string]any{
+			"require-justification": true,
+			"forbidden-linters":     []any{"staticcheck", " unused "},
+			"allowed-linters":       []any{"lll", "errcheck"},
+
+			"forbid-nolint-all":              true,
+			"require-nolint-explanation":     true,
+			"require-nolint-explanation-for": []any{"errcheck"},
+			"require-gosec-rule-ids":         true,
+			"forbidden-gosec-rules":          []any{"G101", "G402"},
+			"forbidden-revive-rules":         []any{"exported"},
+			"check-revive-regions":           true,
+			"max-nolint-lines":               50,
+			"file-level-allowed-linters":     []any{"lll"},
+			"check-nolint-placement":         true,
+			"golangci-compat":                true,
+			"nosec-tag":                      "#falsepositive",
+			"generated-files":                "skip",
+			"scan-ignored-files":             true,
+			"test-files": map[string]any{
+				"forbidden-gosec-rules": []any{},
+			},
+			"overrides": []any{
+				map[string]any{
+					"paths":                 []any{"internal/payments/**"},
+					"forbidden-linters":     []any{"staticcheck"},
+					"require-justification": false,
+				},
+				map[string]any{
+					"paths":             []any{"tools/**"},
+					"forbidden-linters": []any{},
+				},
+			},
+		}
+
+		settings, err := nolintguard.DecodeSettings(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		want := nolintguard.Settings{
+			RequireJustification: true,
+			ForbiddenLinters:     []string{"staticcheck", " unused "},
+			AllowedLinters:       []string{"lll", "errcheck"},
+
+			ForbidNolintAll:             true,
+			RequireNolintExplanation:    true,
+			RequireNolintExplanationFor: []string{"errcheck"},
+			RequireGosecRuleIDs:         true,
+			ForbiddenGosecRules:         []string{"G101", "G402"},
+			ForbiddenReviveRules:        []string{"exported"},
+			CheckReviveRegions:          true,
+			MaxNolintLines:              50,
+			FileLevelAllowedLinters:     []string{"lll"},
+			CheckNolintPlacement:        true,
+			GolangciCompat:              true,
+			NosecTag:                    "#falsepositive",
+			GeneratedFiles:              "skip",
+			ScanIgnoredFiles:            true,
+			TestFiles: &nolintguard.OverrideSettings{
+				ForbiddenGosecRules: []string{},
+			},
+			Overrides: []nolintguard.OverrideSettings{
+				{
+					Paths:                []string{"internal/payments/**"},
+					ForbiddenLinters:     []string{"staticcheck"},
+					RequireJustification: ptr(false),
+				},
+				{
+					Paths:            []string{"tools/**"},
+					ForbiddenLinters: []string{},
+				},
+			},
+		}
+		if !reflect.DeepEqual(settings, want) {
+			t.Fatalf("DecodeSettings() = %+v, want %+v", settings, want)
+		}
+
+		config, err := settings.Config()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantConfig := nolintguard.Config{
+			RequireJustification: true,
+			ForbiddenLinters:     map[string]bool{"staticcheck": true, "unused": true},
+			AllowedLinters:       map[string]bool{"lll": true, "errcheck": true},
+
+			ForbidNolintAll:             true,
+			CustomLinters:               map[string]bool{},
+			RequireNolintExplanation:    true,
+			RequireNolintExplanationFor: map[string]bool{"errcheck": true},
+			RequireGosecRuleIDs:         true,
+			ForbiddenGosecRules:         map[string]bool{"G101": true, "G402": true},
+			ForbiddenReviveRules:        map[string]bool{"exported": true},
+			CheckReviveRegions:          true,
+			MaxNolintLines:              50,
+			FileLevelAllowedLinters:     map[string]bool{"lll": true},
+			CheckNolintPlacement:        true,
+			GolangciCompat:              true,
+			NosecTag:                    "#falsepositive",
+			GeneratedFiles:              "skip",
+			ScanIgnoredFiles:            true,
+			TestFiles: &nolintguard.Override{
+				ForbiddenGosecRules: map[string]bool{},
+			},
+			Overrides: []nolintguard.Override{
+				{
+					Paths:                []string{"internal/payments/**"},
+					ForbiddenLinters:     map[string]bool{"staticcheck": true},
+					RequireJustification: ptr(false),
+				},
+				{
+					Paths:            []string{"tools/**"},
+					ForbiddenLinters: map[string]bool{},
+				},
+			},
+		}
+		if !reflect.DeepEqual(config, wantConfig) {
+			t.Fatalf("Config() = %+v, want %+v", config, wantConfig)
+		}
+	})
+
+	t.Run("nil settings", func(t *testing.T) {
+		settings, err := nolintguard.DecodeSettings(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(settings, nolintguard.Settings{}) {
+			t.Fatalf("DecodeSettings(nil) = %+v, want zero value", settings)
+		}
+	})
+
+	errorCases := []struct {
+		name    string
+		raw     any
+		wantErr string
+	}{
+		{
+			name:    "unknown key",
+			raw:     map[string]any{"forbidden-linter": []any{"unused"}},
+			wantErr: `unknown field "forbidden-linter"`,
+		},
+		{
+			name:    "comma-separated string instead of list",
+			raw:     map[string]any{"forbidden-linters": "staticcheck,unused"},
+			wantErr: "forbidden-linters",
+		},
+		{
+			name:    "unknown key in override",
+			raw:     map[string]any{"overrides": []any{map[string]any{"paths": []any{"tools/**"}, "forbiden-linters": []any{}}}},
+			wantErr: `unknown field "forbiden-linters"`,
+		},
+		{
+			name:    "mistyped boolean",
+			raw:     map[string]any{"require-justification": "yes"},
+			wantErr: "require-justification",
+		},
+	}
+
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := nolintguard.DecodeSettings(tc.raw)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error %q does not mention %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestSettingsConfig(t *testing.T) {
+	cases := []struct {
+		name    string
+		linters []string
+		allowed []string
+		wantErr string
+	}{
+		{name: "empty entry", linters: []string{"unused", " "}, wantErr: "forbidden-linters[1]: linter name is empty"},
+		{name: "comma inside entry", linters: []string{"staticcheck,unused"}, wantErr: "must be a single linter name"},
+		{name: "conflicting allowlist", linters: []string{"errcheck"}, allowed: []string{"errcheck"}, wantErr: "listed in both forbidden and allowed linters"},
+		{name: "empty allowlist entry", allowed: []string{""}, wantErr: "allowed-linters[0]: linter name is empty"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := nolintguard.Settings{ForbiddenLinters: tc.linters, AllowedLinters: tc.allowed}.Config()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("error %q does not mention %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestPlugin(t *testing.T) {
+	newPlugin, err := register.GetPlugin("nolintguard")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newPlugin(map[string]any{
+		"forbidden-linters": []any{"staticcheck", "unused"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mode := p.GetLoadMode(); mode != register.LoadModeSyntax {
+		t.Fatalf("GetLoadMode() = %q, want %q", mode, register.LoadModeSyntax)
+	}
+
+	analyzers, err := p.BuildAnalyzers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(analyzers) != 1 {
+		t.Fatalf("BuildAnalyzers() returned %d analyzers, want 1", len(analyzers))
+	}
+
+	analysistest.Run(t, analysistest.TestData(), analyzers[0], "e")
+
+	if _, err := newPlugin(map[string]any{"forbidden-linters": "unused"}); err == nil {
+		t.Fatal("expected an error for a comma-separated forbidden-linters string")
+	}
+}
@@ -0,0 +1,140 @@
+package nolintguard
+
+import (
+	_ "embed"
+	"slices"
+	"strings"
+)
+
+//go:embed data/golangci-lint.txt
+var golangciLintersData string
+
+// knownLinters holds the linter and formatter names known to the golangci-lint
+// version recorded in data/golangci-lint.txt, sorted alphabetically.
+var knownLinters = parseNameList(golangciLintersData)
+
+//go:embed data/gosec.txt
+var gosecRulesData string
+
+// knownGosecRules holds the rule IDs known to the gosec version recorded in
+// data/gosec.txt, sorted.
+var knownGosecRules = parseNameList(gosecRulesData)
+
+//go:embed data/revive.txt
+var reviveRulesData string
+
+// knownReviveRules holds the rule names known to the revive version recorded in
+// data/revive.txt, sorted alphabetically.
+var knownReviveRules = parseNameList(reviveRulesData)
+
+// linterAliases maps deprecated and aliased linter names to the name golangci-lint
+// uses today. Policy checks run against the canonical name, so an alias cannot be
+// used to bypass them.
+var linterAliases = map[string]string{
+	"gas":              "gosec",
+	"megacheck":        "staticcheck",
+	"gosimple":         "staticcheck",
+	"stylecheck":       "staticcheck",
+	"golint":           "revive",
+	"vet":              "govet",
+	"vetshadow":        "govet",
+	"goerr113":         "err113",
+	"gomnd":            "mnd",
+	"exportloopref":    "copyloopvar",
+	"scopelint":        "copyloopvar",
+	"tenv":             "usetesting",
+	"deadcode":         "unused",
+	"structcheck":      "unused",
+	"varcheck":         "unused",
+	"exhaustivestruct": "exhaustruct",
+	"maligned":         "govet",
+	"logrlint":         "loggercheck",
+	"nosnakecase":      "revive",
+}
+
+// canonicalLinter returns the canonical name for linter and whether linter is a
+// deprecated alias of it.
+func canonicalLinter(linter string) (string, bool) {
+	if canonical, ok := linterAliases[linter]; ok {
+		return canonical, true
+	}
+
+	return linter, false
+}
+
+// parseNameList parses an embedded name list. Blank lines and lines starting
+// with # are ignored. The result is sorted.
+func parseNameList(data string) []string {
+	var names []string
+	for line := range strings.Lines(data) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
+
+// isKnownLinter reports whether name is a golangci-lint linter or one of the
+// custom linters from the configuration.
+func isKnownLinter(name string, config Config) bool {
+	if config.CustomLinters[name] {
+		return true
+	}
+
+	_, found := slices.BinarySearch(knownLinters, name)
+
+	return found
+}
+
+// suggestName returns the candidate closest to name by edit distance, or an
+// empty string if no candidate is close enough to be a plausible typo.
+// Ties are resolved alphabetically.
+func suggestName(name string, candidates ...[]string) string {
+	maxDistance := min(2, max(1, len(name)/3))
+
+	var (
+		best         string
+		bestDistance = maxDistance + 1
+	)
+
+	for _, list := range candidates {
+		for _, candidate := range list {
+			d := editDistance(name, candidate)
+			if d < bestDistance || (d == bestDistance && candidate < best) {
+				best, bestDistance = candidate, d
+			}
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
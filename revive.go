@@ -0,0 +1,223 @@
+package nolintguard
+
+import (
+	"cmp"
+	"fmt"
+	"go/token"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// reviveDirectiveRe matches the head of a revive directive as revive parses it:
+// the action, an optional -line or -next-line modifier and an optional
+// comma-separated rule list.
+var reviveDirectiveRe = regexp.MustCompile(`^revive:(enable|disable)(-line|-next-line)?(?::(\S+))?`)
+
+// parseReviveRules returns the head of a revive directive without its rule list
+// (e.g. revive:disable-next-line), whether it disables rules, and the rules it names.
+// Empty entries in the rule list are skipped, as revive does. ok is false if text
+// is not a well-formed revive directive.
+func parseReviveRules(text string) (head string, disable bool, rules []string, ok bool) {
+	match := reviveDirectiveRe.FindStringSubmatch(text)
+	if match == nil {
+		return "", false, nil, false
+	}
+
+	for rule := range strings.SplitSeq(match[3], ",") {
+		if rule != "" {
+			rules = append(rules, rule)
+		}
+	}
+
+	return "revive:" + match[1] + match[2], match[1] == "disable", rules, true
+}
+
+// checkReviveRules validates the rule list of a //revive: directive: rule names
+// unknown to revive are reported when ValidateReviveRules is set, and disabling a
+// rule from ForbiddenReviveRules is always reported. A directive disabling every
+// rule is reported as well when forbidden rules are configured.
+func checkReviveRules(pass *analysis.Pass, pos token.Pos, text string, config Config) {
+	head, disable, rules, ok := parseReviveRules(text)
+	if !ok {
+		return
+	}
+
+	if disable && len(rules) == 0 && len(config.ForbiddenReviveRules) > 0 {
+		pass.Reportf(pos, "nolintguard: //%s without rule names also disables forbidden revive rules %s", head, strings.Join(slices.Sorted(maps.Keys(config.ForbiddenReviveRules)), ", "))
+		return
+	}
+
+	for _, rule := range rules {
+		if config.ValidateReviveRules {
+			if _, found := slices.BinarySearch(knownReviveRules, rule); !found {
+				reportUnknownReviveRule(pass, pos, head, rule)
+				continue
+			}
+		}
+		if disable && config.ForbiddenReviveRules[rule] {
+			pass.Reportf(pos, "nolintguard: //%s:%s is forbidden", head, rule)
+		}
+	}
+}
+
+// reportUnknownReviveRule reports a rule name that revive does not know,
+// suggesting the closest known rule name when there is a plausible match.
+func reportUnknownReviveRule(pass *analysis.Pass, pos token.Pos, head, rule string) {
+	if suggestion := suggestName(rule, knownReviveRules); suggestion != "" {
+		pass.Reportf(pos, "nolintguard: //%s:%s names an unknown revive rule; did you mean %q?", head, rule, suggestion)
+		return
+	}
+
+	pass.Reportf(pos, "nolintguard: //%s:%s names an unknown revive rule", head, rule)
+}
+
+// reviveRegions tracks the revive rules disabled by //revive:disable directives
+// in a file, mirroring revive's own per-rule state: a directive that repeats the
+// current state of a rule is a no-op.
+type reviveRegions struct {
+	// report enables the diagnostics for unbalanced directives. Regions are
+	// tracked either way, so their size can be checked.
+	report bool
+
+	// open maps each disabled rule to the directive that disabled it. The empty
+	// rule name stands for a directive disabling every rule.
+	open map[string]token.Pos
+
+	// reenabled holds the rules enabled again while every rule is disabled.
+	reenabled map[string]bool
+
+	// names holds the text of each directive that opened a region, as shown in
+	// messages (e.g. //revive:disable:var-naming).
+	names map[token.Pos]string
+
+	// ends maps each directive that opened a region to the furthest directive
+	// closing one of its rules, or to the end of the file.
+	ends map[token.Pos]token.Pos
+}
+
+// newReviveRegions returns an empty region tracker for a file. report enables
+// the diagnostics for unbalanced directives.
+func newReviveRegions(report bool) *reviveRegions {
+	return &reviveRegions{
+		report:    report,
+		open:      make(map[string]token.Pos),
+		reenabled: make(map[string]bool),
+		names:     make(map[token.Pos]string),
+		ends:      make(map[token.Pos]token.Pos),
+	}
+}
+
+// add records a //revive: directive at pos, reporting redundant disables and
+// enables without a preceding disable. Directives limited to a single line
+// (-line, -next-line) do not open or close regions.
+func (r *reviveRegions) add(pass *analysis.Pass, pos token.Pos, text string) {
+	head, disable, rules, ok := parseReviveRules(text)
+	if !ok || strings.HasSuffix(head, "-line") {
+		return
+	}
+
+	switch {
+	case disable && len(rules) == 0:
+		if opened, ok := r.open[""]; ok {
+			r.reportRedundant(pass, pos, "//revive:disable is redundant; every revive rule is already disabled", opened, "every revive rule disabled here")
+			return
+		}
+		r.disable("", pos, text)
+		clear(r.reenabled)
+	case disable:
+		for _, rule := range rules {
+			if opened, ok := r.open[rule]; ok {
+				r.reportRedundant(pass, pos, fmt.Sprintf("//revive:disable:%s is redundant; the rule is already disabled", rule), opened, fmt.Sprintf("revive rule %s disabled here", rule))
+				continue
+			}
+			if opened, ok := r.open[""]; ok && !r.reenabled[rule] {
+				r.reportRedundant(pass, pos, fmt.Sprintf("//revive:disable:%s is redundant; every revive rule is already disabled", rule), opened, "every revive rule disabled here")
+				continue
+			}
+			if r.reenabled[rule] {
+				delete(r.reenabled, rule)
+				continue
+			}
+			r.disable(rule, pos, text)
+		}
+	case len(rules) == 0:
+		if len(r.open) == 0 {
+			if r.report {
+				pass.Reportf(pos, "nolintguard: //revive:enable without a preceding //revive:disable")
+			}
+			return
+		}
+		for rule := range r.open {
+			r.enable(rule, pos)
+		}
+		clear(r.reenabled)
+	default:
+		for _, rule := range rules {
+			_, disabled := r.open[rule]
+			_, all := r.open[""]
+			switch {
+			case disabled:
+				r.enable(rule, pos)
+				if all {
+					r.reenabled[rule] = true
+				}
+			case all && !r.reenabled[rule]:
+				r.reenabled[rule] = true
+			case r.report:
+				pass.Reportf(pos, "nolintguard: //revive:enable:%s without a preceding //revive:disable", rule)
+			}
+		}
+	}
+}
+
+// disable opens a region for rule at the directive at pos.
+func (r *reviveRegions) disable(rule string, pos token.Pos, text string) {
+	r.open[rule] = pos
+	r.names[pos] = "//" + strings.Fields(text)[0]
+}
+
+// enable closes the region for rule at the directive at pos.
+func (r *reviveRegions) enable(rule string, pos token.Pos) {
+	opened := r.open[rule]
+	r.ends[opened] = max(r.ends[opened], pos)
+	delete(r.open, rule)
+}
+
+// finish closes the regions still open at end, the end of the file, and reports
+// them as never re-enabled.
+func (r *reviveRegions) finish(pass *analysis.Pass, end token.Pos) {
+	rules := slices.SortedFunc(maps.Keys(r.open), func(a, b string) int {
+		return cmp.Or(cmp.Compare(r.open[a], r.open[b]), cmp.Compare(a, b))
+	})
+
+	for _, rule := range rules {
+		opened := r.open[rule]
+		r.enable(rule, end)
+		if !r.report {
+			continue
+		}
+		if rule == "" {
+			pass.Reportf(opened, "nolintguard: //revive:disable is never re-enabled; revive stays disabled for the rest of the file")
+			continue
+		}
+		pass.Reportf(opened, "nolintguard: //revive:disable:%s is never re-enabled; the rule stays disabled for the rest of the file", rule)
+	}
+}
+
+// reportRedundant reports a disable directive that repeats the current state,
+// pointing at the directive that opened the region.
+func (r *reviveRegions) reportRedundant(pass *analysis.Pass, pos token.Pos, message string, opened token.Pos, related string) {
+	if !r.report {
+		return
+	}
+
+	pass.Report(analysis.Diagnostic{
+		Pos:     pos,
+		Message: "nolintguard: " + message,
+		Related: []analysis.RelatedInformation{{Pos: opened, Message: related}},
+	})
+}
@@ -0,0 +1,343 @@
+package nolintguard
+
+import (
+	"go/ast"
+	"go/token"
+	"maps"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// suppressedSpans computes the lines each directive in a file actually suppresses,
+// following the attachment rules of the tool that honours the directive.
+type suppressedSpans struct {
+	pass *analysis.Pass
+	file *ast.File
+
+	// nodeEnds maps the line and column where AST nodes start to the last line
+	// covered by any of them. Built on first use.
+	nodeEnds map[lineColumn]int
+
+	// nodeStarts maps the line and column where AST nodes start to the outermost
+	// of them. Built with nodeEnds.
+	nodeStarts map[lineColumn]ast.Node
+
+	// lineCode maps each line to the position of the first code or comment on
+	// it, i.e. the earliest position where a node other than the file starts or
+	// ends. Built with nodeEnds.
+	lineCode map[int]token.Pos
+
+	// groupNodes maps each comment group to the nodes go/ast associates it with.
+	// Built on first use.
+	groupNodes map[*ast.CommentGroup][]ast.Node
+}
+
+// lineColumn is a position within a file.
+type lineColumn struct {
+	line, column int
+}
+
+// indexNodes records, for every line and column where AST nodes start, the
+// outermost node other than a comment starting there and the last line covered
+// by any of them, and for every line the first code or comment on it.
+func (s *suppressedSpans) indexNodes() {
+	if s.nodeEnds != nil {
+		return
+	}
+
+	s.nodeEnds = make(map[lineColumn]int)
+	s.nodeStarts = make(map[lineColumn]ast.Node)
+	s.lineCode = make(map[int]token.Pos)
+	ast.Inspect(s.file, func(n ast.Node) bool {
+		if n == nil {
+			return false
+		}
+		if n != s.file {
+			s.addCode(n.Pos())
+			s.addCode(n.End() - 1)
+		}
+		pos := s.pass.Fset.Position(n.Pos())
+		key := lineColumn{pos.Line, pos.Column}
+		s.nodeEnds[key] = max(s.nodeEnds[key], s.pass.Fset.Position(n.End()).Line)
+		// golangci-lint extends a directive over a following comment line, but
+		// that suppresses nothing, so comments do not count as attachments
+		switch n.(type) {
+		case *ast.Comment, *ast.CommentGroup:
+		default:
+			if _, ok := s.nodeStarts[key]; !ok {
+				s.nodeStarts[key] = n
+			}
+		}
+		return true
+	})
+	for _, group := range s.file.Comments {
+		for _, comment := range group.List {
+			s.addCode(comment.Pos())
+			s.addCode(comment.End() - 1)
+		}
+	}
+}
+
+// addCode records code at pos.
+func (s *suppressedSpans) addCode(pos token.Pos) {
+	line := s.pass.Fset.Position(pos).Line
+	if first, ok := s.lineCode[line]; !ok || pos < first {
+		s.lineCode[line] = pos
+	}
+}
+
+// followsCode reports whether comment shares its line with code before it, such
+// as a trailing comment. Other comments before it on the line count as code too.
+func (s *suppressedSpans) followsCode(comment *ast.Comment) bool {
+	s.indexNodes()
+
+	first, ok := s.lineCode[s.pass.Fset.Position(comment.Pos()).Line]
+
+	return ok && first < comment.Pos()
+}
+
+// nolintNode returns the outermost node golangci-lint extends a //nolint directive
+// in group to: the node starting on the line after the comment group, at the
+// group's column, or nil if there is none. golangci-lint matches the whole group,
+// so a directive inside a doc comment still applies to the declaration below.
+func (s *suppressedSpans) nolintNode(group *ast.CommentGroup) ast.Node {
+	s.indexNodes()
+
+	pos := s.pass.Fset.Position(group.Pos())
+	end := s.pass.Fset.Position(group.End()).Line
+
+	return s.nodeStarts[lineColumn{end + 1, pos.Column}]
+}
+
+// nolintSpan returns the lines covered by a //nolint directive in comment, the
+// way golangci-lint computes them: the comment's own line, extended to the end of
+// the nodes starting on the line after the comment group at the group's column.
+func (s *suppressedSpans) nolintSpan(group *ast.CommentGroup, comment *ast.Comment) (start, end int) {
+	s.indexNodes()
+
+	pos := s.pass.Fset.Position(group.Pos())
+	groupEnd := s.pass.Fset.Position(group.End()).Line
+
+	start = s.pass.Fset.Position(comment.Pos()).Line
+	end = max(s.pass.Fset.Position(comment.End()).Line, s.nodeEnds[lineColumn{groupEnd + 1, pos.Column}])
+
+	return start, end
+}
+
+// gosecSpan returns the lines covered by a #nosec or //gosec: directive in comment,
+// the way gosec computes them: the extent of the nodes go/ast associates the
+// comment's group with.
+func (s *suppressedSpans) gosecSpan(group *ast.CommentGroup, comment *ast.Comment) (start, end int) {
+	start = s.pass.Fset.Position(comment.Pos()).Line
+	end = s.pass.Fset.Position(comment.End()).Line
+	for _, node := range s.gosecNodes(group) {
+		start = min(start, s.pass.Fset.Position(node.Pos()).Line)
+		end = max(end, s.pass.Fset.Position(node.End()).Line)
+	}
+
+	return start, end
+}
+
+// gosecNodes returns the nodes a #nosec or //gosec: directive in group applies
+// to, the way gosec attaches them: the nodes go/ast associates the group with.
+func (s *suppressedSpans) gosecNodes(group *ast.CommentGroup) []ast.Node {
+	if s.groupNodes == nil {
+		s.groupNodes = make(map[*ast.CommentGroup][]ast.Node)
+		for node, groups := range ast.NewCommentMap(s.pass.Fset, s.file, s.file.Comments) {
+			for _, g := range groups {
+				s.groupNodes[g] = append(s.groupNodes[g], node)
+			}
+		}
+	}
+
+	return s.groupNodes[group]
+}
+
+// appliesToFile reports whether the directive in comment suppresses the whole
+// file: a //nolint directive in the comment group directly above the package
+// clause, as golangci-lint then expands it to the *ast.File node, or a #nosec or
+// //gosec: directive that go/ast associates with the file, as gosec then applies
+// it to every node.
+func (s *suppressedSpans) appliesToFile(group *ast.CommentGroup, comment *ast.Comment, d directive) bool {
+	if d.tag != "" || strings.HasPrefix(d.text, "gosec:") {
+		for _, node := range s.gosecNodes(group) {
+			if node == s.file {
+				return true
+			}
+		}
+		return false
+	}
+
+	return s.nolintNode(group) == s.file
+}
+
+// checkFileLevelSuppression reports a //nolint, #nosec or //gosec: directive that
+// suppresses the whole file, unless every linter it suppresses is allowed at file level.
+func checkFileLevelSuppression(pass *analysis.Pass, spans *suppressedSpans, group *ast.CommentGroup, comment *ast.Comment, d directive, config Config) {
+	var name string
+	var linters []string
+
+	switch {
+	case d.tag != "":
+		name, linters = d.tag, []string{"gosec"}
+	case strings.HasPrefix(d.text, "gosec:"):
+		name, linters = "//"+strings.Fields(d.text)[0], []string{"gosec"}
+	case strings.HasPrefix(d.text, "nolint"):
+		name = "//" + d.text
+		if list, ok := nolintLinterList(d.text); ok {
+			for _, linter := range parseLinters(list) {
+				canonical, _ := canonicalLinter(linter)
+				linters = append(linters, canonical)
+			}
+		} else {
+			linters = []string{"all"}
+		}
+	default:
+		// revive regions are checked once the whole file has been seen
+		return
+	}
+
+	if !spans.appliesToFile(group, comment, d) {
+		return
+	}
+
+	reportFileLevelSuppression(pass, d.pos, name, linters, config)
+}
+
+// checkFileLevelReviveRegions reports //revive:disable regions that suppress the
+// whole file: regions opened before the first declaration and never re-enabled.
+func checkFileLevelReviveRegions(pass *analysis.Pass, file *ast.File, regions *reviveRegions, config Config) {
+	first := file.End()
+	if len(file.Decls) > 0 {
+		first = file.Decls[0].Pos()
+	}
+
+	for _, opened := range slices.Sorted(maps.Keys(regions.ends)) {
+		if opened < first && regions.ends[opened] == file.End() {
+			reportFileLevelSuppression(pass, opened, regions.names[opened], []string{"revive"}, config)
+		}
+	}
+}
+
+// reportFileLevelSuppression reports a file-level directive at pos suppressing
+// linters, unless all of them are allowed at file level.
+func reportFileLevelSuppression(pass *analysis.Pass, pos token.Pos, name string, linters []string, config Config) {
+	var forbidden []string
+	for _, linter := range linters {
+		if !config.FileLevelAllowedLinters[linter] && !slices.Contains(forbidden, linter) {
+			forbidden = append(forbidden, linter)
+		}
+	}
+
+	if len(forbidden) > 0 {
+		pass.Reportf(pos, "nolintguard: %s applies to the whole file; file-level suppression of %s is forbidden", name, strings.Join(forbidden, ", "))
+	}
+}
+
+// checkNolintPlacement reports a //nolint directive on its own line that
+// golangci-lint does not attach to any node, so that it only suppresses its own
+// line, or that it attaches to a whole compound statement inside a function.
+func checkNolintPlacement(pass *analysis.Pass, spans *suppressedSpans, group *ast.CommentGroup, comment *ast.Comment, d directive) {
+	if !strings.HasPrefix(d.text, "nolint") || !honoredByGolangci(comment, d) {
+		return
+	}
+
+	// A directive sharing its line with code applies to that line
+	if spans.followsCode(comment) {
+		return
+	}
+
+	node := spans.nolintNode(group)
+	switch {
+	case node == nil:
+		pass.Reportf(d.pos, "nolintguard: //%s is not attached to any code; golangci-lint applies it to its own comment only", d.text)
+	case node == spans.file:
+		// Directives above the package clause are file-level suppressions
+	default:
+		if kind := compoundStmtKind(node); kind != "" {
+			start, end := pass.Fset.Position(node.Pos()).Line, pass.Fset.Position(node.End()).Line
+			pass.Reportf(d.pos, "nolintguard: //%s applies to the whole %s (lines %d-%d); place it on the line that needs it", d.text, kind, start, end)
+		}
+	}
+}
+
+// compoundStmtKind describes a statement containing other statements, or returns
+// an empty string for any other node.
+func compoundStmtKind(node ast.Node) string {
+	switch node := node.(type) {
+	case *ast.BlockStmt:
+		return "block"
+	case *ast.IfStmt:
+		return "if statement"
+	case *ast.ForStmt, *ast.RangeStmt:
+		return "for statement"
+	case *ast.SwitchStmt, *ast.TypeSwitchStmt:
+		return "switch statement"
+	case *ast.SelectStmt:
+		return "select statement"
+	case *ast.LabeledStmt:
+		return compoundStmtKind(node.Stmt)
+	default:
+		return ""
+	}
+}
+
+// checkSuppressedSpan reports a directive covering more lines than the limit
+// configured for its kind.
+func checkSuppressedSpan(pass *analysis.Pass, spans *suppressedSpans, group *ast.CommentGroup, comment *ast.Comment, d directive, config Config) {
+	var (
+		name       string
+		limit      int
+		start, end int
+	)
+
+	switch {
+	case d.tag != "":
+		if config.MaxNosecLines == 0 {
+			return
+		}
+		name, limit = d.tag, config.MaxNosecLines
+		start, end = spans.gosecSpan(group, comment)
+	case strings.HasPrefix(d.text, "gosec:"):
+		if config.MaxNosecLines == 0 {
+			return
+		}
+		name, limit = "//"+strings.Fields(d.text)[0], config.MaxNosecLines
+		start, end = spans.gosecSpan(group, comment)
+	case strings.HasPrefix(d.text, "nolint"):
+		if config.MaxNolintLines == 0 {
+			return
+		}
+		name, limit = "//"+d.text, config.MaxNolintLines
+		start, end = spans.nolintSpan(group, comment)
+	default:
+		// revive regions are checked once the whole file has been seen
+		return
+	}
+
+	reportSuppressedSpan(pass, d.pos, name, start, end, limit)
+}
+
+// checkReviveSpans reports //revive:disable regions covering more lines than
+// MaxReviveLines.
+func checkReviveSpans(pass *analysis.Pass, regions *reviveRegions, config Config) {
+	if config.MaxReviveLines == 0 {
+		return
+	}
+
+	for _, opened := range slices.Sorted(maps.Keys(regions.ends)) {
+		closed := regions.ends[opened]
+		start, end := pass.Fset.Position(opened).Line, pass.Fset.Position(closed).Line
+		reportSuppressedSpan(pass, opened, regions.names[opened], start, end, config.MaxReviveLines)
+	}
+}
+
+// reportSuppressedSpan reports a directive at pos suppressing lines start to end
+// if they are more than limit.
+func reportSuppressedSpan(pass *analysis.Pass, pos token.Pos, name string, start, end, limit int) {
+	if lines := end - start + 1; lines > limit {
+		pass.Reportf(pos, "nolintguard: %s suppresses %d lines (%d-%d), more than the limit of %d", name, lines, start, end, limit)
+	}
+}
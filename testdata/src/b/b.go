@@ -4,28 +4,28 @@ import (
 	"crypto/md5"
 )
 
-// Test case: #nosec without justification (will fail when require-justification is enabled)
+// Test case: nosec without justification (will fail when require-justification is enabled)
 func nosecNoJustification() {
 	// #nosec G401 // want "nolintguard: #nosec directive must include justification \\(-- reason\\)"
 	h := md5.New()
 	_ = h
 }
 
-// Test case: #nosec with justification (OK)
+// Test case: nosec with justification (OK)
 func nosecWithJustification() {
 	// #nosec G401 -- Using MD5 for non-cryptographic checksums only
 	h := md5.New()
 	_ = h
 }
 
-// Test case: #nosec with empty justification (will fail)
+// Test case: nosec with empty justification (will fail)
 func nosecEmptyJustification() {
 	// #nosec G401 -- // want "nolintguard: #nosec directive must include justification \\(-- reason\\)"
 	h := md5.New()
 	_ = h
 }
 
-// Test case: #nosec with whitespace-only justification (will fail)
+// Test case: nosec with whitespace-only justification (will fail)
 func nosecWhitespaceJustification() {
 	// #nosec G401 --   // want "nolintguard: #nosec directive must include justification \\(-- reason\\)"
 	h := md5.New()
@@ -60,7 +60,7 @@ func gosecDisableWithJustification() {
 	_ = apiKey
 }
 
-// Test case: #nosec without rules but with justification (OK)
+// Test case: nosec without rules but with justification (OK)
 func nosecNoRulesWithJustification() {
 	// #nosec -- Verified safe after security review
 	h := md5.New()
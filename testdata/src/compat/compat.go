@@ -0,0 +1,59 @@
+package compat
+
+// Test //nolint directives golangci-lint does not recognize
+
+import "os"
+
+func canonical() {
+	os.Remove("a") //nolint:errcheck // Best effort cleanup (should pass)
+	os.Remove("b") // nolint:errcheck // A space after the slashes is accepted (should pass)
+}
+
+func blockComment() {
+	// want +1 "nolintguard: ineffective directive: golangci-lint does not recognize \"nolint:errcheck\"; use //nolint:errcheck"
+	os.Remove("a") /* nolint:errcheck */
+}
+
+func blockCommentBeforeCode() {
+	// want +1 "nolintguard: ineffective directive: golangci-lint does not recognize \"nolint:errcheck\"; use //nolint:errcheck"
+	/* nolint:errcheck */ os.Remove("a")
+}
+
+func upperCase() {
+	// want +1 "nolintguard: ineffective directive: golangci-lint does not recognize \"NOLINT:ErrCheck\"; use //nolint:errcheck"
+	os.Remove("a") //NOLINT:ErrCheck // Best effort cleanup
+}
+
+func tabIndented() {
+	// want +1 "nolintguard: ineffective directive: golangci-lint does not recognize \"nolint:errcheck\"; use //nolint:errcheck"
+	os.Remove("a") //	nolint:errcheck
+}
+
+func stacked() {
+	// want +1 "nolintguard: ineffective directive: golangci-lint does not recognize \"nolint:gosec\"; use //nolint:gosec"
+	os.Remove("a") //nolint:errcheck //nolint:gosec
+}
+
+func spaceBeforeColon() {
+	// want +1 "nolintguard: golangci-lint reads \"nolint :errcheck\" as a bare //nolint suppressing every linter; use //nolint:errcheck"
+	os.Remove("a") //nolint :errcheck
+}
+
+func spaceInsteadOfColon() {
+	// want +1 "nolintguard: golangci-lint reads \"nolint errcheck\" as a bare //nolint suppressing every linter; use //nolint:errcheck"
+	os.Remove("a") //nolint errcheck // Best effort cleanup
+}
+
+func spaceSeparatedLinters() {
+	// want +1 "nolintguard: golangci-lint reads \"nolint errcheck gosec\" as a bare //nolint suppressing every linter; use //nolint:errcheck,gosec"
+	os.Remove("a") //nolint errcheck gosec
+}
+
+func tabInsteadOfColon() {
+	// want +1 `nolintguard: ineffective directive: golangci-lint does not recognize "nolint\\terrcheck"; use //nolint:errcheck`
+	os.Remove("a") //nolint	errcheck
+}
+
+func bareWithWords() {
+	os.Remove("a") //nolint legacy code (a bare //nolint, should pass)
+}
@@ -64,7 +64,7 @@ func colonAndSpaces() {
 
 // Test case: Very long linter list
 func longLinterList() {
-	//nolint:errcheck,ineffassign,staticcheck,unused,deadcode,varcheck,structcheck,gosec,typecheck,bodyclose // want "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//nolint:errcheck,ineffassign,staticcheck,unused,deadcode,varcheck,structcheck,gosec,typecheck,bodyclose // want "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead" "nolintguard: //nolint:deadcode uses a deprecated linter name; use unused instead" "nolintguard: //nolint:varcheck uses a deprecated linter name; use unused instead" "nolintguard: //nolint:structcheck uses a deprecated linter name; use unused instead"
 	h := md5.New()
 	_ = h
 }
@@ -0,0 +1,10 @@
+package generated
+
+// Test hand-written code next to a generated file, checked as usual
+
+import "os"
+
+func cleanup() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	os.Remove("a") //nolint:gosec
+}
@@ -0,0 +1,10 @@
+package generatedskip
+
+// Test hand-written code next to a skipped generated file, checked as usual
+
+import "os"
+
+func cleanup() {
+	// want +1 "nolintguard: #nosec directive must include justification \\(-- reason\\)"
+	os.Remove("a") // #nosec
+}
@@ -9,14 +9,14 @@ import (
 	"crypto/md5"
 )
 
-// Test case: #nosec without justification (should fail)
+// Test case: nosec without justification (should fail)
 func nosecNoJustification() {
 	// #nosec G401 // want "nolintguard: #nosec directive must include justification \\(-- reason\\)"
 	h := md5.New()
 	_ = h
 }
 
-// Test case: #nosec with justification (should pass)
+// Test case: nosec with justification (should pass)
 func nosecWithJustification() {
 	// #nosec G401 -- Using MD5 for checksums only
 	h := md5.New()
@@ -46,7 +46,7 @@ func allowedErrcheck() {
 
 // Test case: gosec in nolint (always forbidden)
 func gosecInNolint() {
-	//nolint:gosec // want "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//nolint:gosec // want "nolintguard: //nolint:gosec is forbidden; use [#]nosec or //gosec:disable instead"
 	h := md5.New()
 	_ = h
 }
@@ -60,7 +60,7 @@ func reviveInNolint() {
 
 // Test case: Multiple forbidden linters including gosec
 func multipleForbiddenWithGosec() {
-	//nolint:gosec,staticcheck,unused // want "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead" "nolintguard: //nolint:staticcheck is forbidden" "nolintguard: //nolint:unused is forbidden"
+	//nolint:gosec,staticcheck,unused // want "nolintguard: //nolint:gosec is forbidden; use [#]nosec or //gosec:disable instead" "nolintguard: //nolint:staticcheck is forbidden" "nolintguard: //nolint:unused is forbidden"
 	h := md5.New()
 	_ = h
 }
@@ -109,7 +109,7 @@ func mixedLinters() {
 
 // Test case: All three types of forbidden (gosec, revive, custom)
 func allThreeForbidden() {
-	//nolint:gosec,revive,staticcheck // want "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead" "nolintguard: //nolint:revive is forbidden; use native revive directives instead" "nolintguard: //nolint:staticcheck is forbidden"
+	//nolint:gosec,revive,staticcheck // want "nolintguard: //nolint:gosec is forbidden; use [#]nosec or //gosec:disable instead" "nolintguard: //nolint:revive is forbidden; use native revive directives instead" "nolintguard: //nolint:staticcheck is forbidden"
 	h := md5.New()
 	_ = h
 }
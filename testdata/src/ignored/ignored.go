@@ -0,0 +1,10 @@
+package ignored
+
+// Test files excluded by build constraints being checked too
+
+import "os"
+
+func cleanup() {
+	// Reported as a forbidden gosec suppression (checked in the test)
+	os.Remove("a") //nolint:gosec
+}
@@ -0,0 +1,12 @@
+//go:build windows
+
+package ignored
+
+// Test a platform-specific file excluded from the current build
+
+import "os"
+
+func cleanupWindows() {
+	// Reported as a forbidden gosec suppression (checked in the test)
+	os.Remove("a") //nolint:gosec
+}
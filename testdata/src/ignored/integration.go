@@ -0,0 +1,12 @@
+//go:build integration
+
+package ignored
+
+// Test a file excluded by a custom build tag
+
+import "os"
+
+func cleanupIntegration() {
+	// Reported as a forbidden revive suppression (checked in the test)
+	os.Remove("a") //nolint:revive
+}
@@ -0,0 +1,6 @@
+//go:build ignore
+
+// Test a template excluded by build constraints that is not Go source: it has
+// no package clause, so it is skipped
+
+{{ template "cleanup" }}
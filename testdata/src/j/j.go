@@ -0,0 +1,69 @@
+package j
+
+// Test suggested fixes rewriting //nolint:gosec into #nosec
+
+import (
+	"crypto/md5"
+)
+
+// Test case: gosec alone with explanation
+func gosecWithExplanation() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//nolint:gosec // MD5 is used for checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: trailing gosec without explanation
+func gosecWithoutExplanation() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	h := md5.New() //nolint:gosec
+	_ = h
+}
+
+// Test case: gosec with other linters keeps a residual nolint directive
+func gosecWithOthers() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//nolint:errcheck,gosec,unused // legacy code
+	h := md5.New()
+	_ = h
+}
+
+// Test case: duplicated gosec entries are rewritten once
+func duplicatedGosec() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead" "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//nolint:gosec,gosec
+	h := md5.New()
+	_ = h
+}
+
+// Test case: space after // and whitespace in the list
+func spacedDirective() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	// nolint: gosec , errcheck
+	h := md5.New()
+	_ = h
+}
+
+// Test case: block comment keeps its style
+func blockComment() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	/* nolint:gosec */
+	h := md5.New()
+	_ = h
+}
+
+// Test case: trailing gosec with other linters puts #nosec on the line above
+func trailingWithOthers() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	h := md5.New() //nolint:gosec,errcheck // legacy code
+	_ = h
+}
+
+// Test case: block comment with other linters has no fix
+func blockCommentWithOthers() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	/* nolint:gosec,errcheck */
+	h := md5.New()
+	_ = h
+}
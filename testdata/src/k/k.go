@@ -0,0 +1,62 @@
+package k
+
+// Test suggested fixes rewriting //nolint:revive into native revive directives
+
+import (
+	"errors"
+)
+
+// Test case: own-line directive becomes disable-next-line
+func ownLine() error {
+	// want +1 "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	//nolint:revive // error text is part of the public API
+	return errors.New("Test")
+}
+
+// Test case: trailing directive becomes disable-line
+func trailing() error {
+	// want +1 "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	return errors.New("Test") //nolint:revive // legacy message
+}
+
+// Test case: trailing directive without explanation
+func trailingNoExplanation() error {
+	// want +1 "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	return errors.New("Test") //nolint:revive
+}
+
+// Test case: own-line directive keeps other linters
+func ownLineWithOthers() error {
+	// want +1 "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	//nolint:errcheck,revive,unused // legacy message
+	return errors.New("Test")
+}
+
+// Test case: trailing directive keeps other linters
+func trailingWithOthers() error {
+	// want +1 "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	return errors.New("Test") //nolint:revive,errcheck // legacy message
+}
+
+// Test case: gosec and revive together only offer the gosec rewrite
+func withGosec() error {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead" "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	//nolint:gosec,revive // reviewed
+	return errors.New("Test")
+}
+
+// Test case: block comment has no fix
+func blockComment() error {
+	// want +1 "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	/* nolint:revive */
+	return errors.New("Test")
+}
+
+// Test case: own-line directive followed by more comments has no fix, since
+// //revive:disable-next-line would cover the next comment instead of the code
+func ownLineFollowedByComment() error {
+	// want +1 "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	//nolint:revive // legacy API
+	// Callers match on this message.
+	return errors.New("Test")
+}
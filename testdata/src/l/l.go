@@ -0,0 +1,50 @@
+package l
+
+// Test allowlist mode
+// This file is tested with allowed-linters=lll,errcheck,funlen
+
+import (
+	"crypto/md5"
+)
+
+// Test case: allowed linter (should pass)
+func allowedErrcheck() {
+	//nolint:errcheck
+	x := 1
+	_ = x
+}
+
+// Test case: several allowed linters (should pass)
+func allowedSeveral() {
+	//nolint:lll,funlen
+	x := 1
+	_ = x
+}
+
+// Test case: linter outside the allowlist (should fail)
+func notAllowed() {
+	//nolint:staticcheck // want "nolintguard: //nolint:staticcheck is not in the allowed linters list"
+	x := 1
+	_ = x
+}
+
+// Test case: mix of allowed and not allowed linters
+func mixed() {
+	//nolint:errcheck,unused,lll,dupl // want "nolintguard: //nolint:unused is not in the allowed linters list" "nolintguard: //nolint:dupl is not in the allowed linters list"
+	x := 1
+	_ = x
+}
+
+// Test case: gosec keeps its dedicated message in allowlist mode
+func gosecInAllowlistMode() {
+	//nolint:gosec // want "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	h := md5.New()
+	_ = h
+}
+
+// Test case: plain nolint is not affected by the allowlist
+func plainNolint() {
+	//nolint
+	x := 1
+	_ = x
+}
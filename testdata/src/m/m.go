@@ -0,0 +1,72 @@
+package m
+
+// Test explanation requirement for every //nolint directive
+// This file is tested with require-nolint-explanation=true
+
+// Test case: nolint with explanation (should pass)
+func withExplanation() {
+	//nolint:errcheck // the error is always nil here
+	x := 1
+	_ = x
+}
+
+// Test case: nolint without explanation (should fail)
+func withoutExplanation() {
+	// want +1 "nolintguard: //nolint directive must include explanation \\(// reason\\)"
+	//nolint:errcheck
+	x := 1
+	_ = x
+}
+
+// Test case: empty explanation (should fail)
+func emptyExplanation() {
+	// want +1 "nolintguard: //nolint directive must include explanation \\(// reason\\)"
+	//nolint:errcheck //
+	x := 1
+	_ = x
+}
+
+// Test case: whitespace-only explanation (should fail)
+func whitespaceExplanation() {
+	// want +1 "nolintguard: //nolint directive must include explanation \\(// reason\\)"
+	//nolint:lll //   
+	x := 1
+	_ = x
+}
+
+// Test case: words after a bare nolint are no explanation (should fail)
+func bareNolintWithWords() {
+	// want +1 "nolintguard: //nolint directive must include explanation \\(// reason\\)"
+	//nolint legacy code
+	x := 1
+	_ = x
+}
+
+// Test case: plain nolint without explanation (should fail)
+func plainNolint() {
+	// want +1 "nolintguard: //nolint directive must include explanation \\(// reason\\)"
+	//nolint
+	x := 1
+	_ = x
+}
+
+// Test case: plain nolint with explanation (should pass)
+func plainNolintWithExplanation() {
+	//nolint // generated-like code kept for compatibility
+	x := 1
+	_ = x
+}
+
+// Test case: trailing nolint without explanation (should fail)
+func trailingWithoutExplanation() {
+	// want +1 "nolintguard: //nolint directive must include explanation \\(// reason\\)"
+	x := 1 //nolint:ineffassign
+	_ = x
+}
+
+// Test case: other comments are not affected
+func regularComment() {
+	// nothing to see here
+	x := 1
+	_ = x
+}
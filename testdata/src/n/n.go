@@ -0,0 +1,41 @@
+package n
+
+// Test explanation requirement for specific linters
+// This file is tested with require-nolint-explanation-for=errcheck,staticcheck
+
+// Test case: listed linter without explanation (should fail)
+func errcheckWithoutExplanation() {
+	// want +1 "nolintguard: //nolint:errcheck directive must include explanation \\(// reason\\)"
+	//nolint:errcheck
+	x := 1
+	_ = x
+}
+
+// Test case: listed linter with explanation (should pass)
+func errcheckWithExplanation() {
+	//nolint:errcheck // Close error is irrelevant for read-only files
+	x := 1
+	_ = x
+}
+
+// Test case: unlisted linter without explanation (should pass)
+func lllWithoutExplanation() {
+	//nolint:lll
+	x := 1
+	_ = x
+}
+
+// Test case: listed linter among others is reported once
+func mixedWithoutExplanation() {
+	// want +1 "nolintguard: //nolint:staticcheck directive must include explanation \\(// reason\\)"
+	//nolint:lll,staticcheck,errcheck
+	x := 1
+	_ = x
+}
+
+// Test case: plain nolint is not tied to a linter (should pass)
+func plainNolint() {
+	//nolint
+	x := 1
+	_ = x
+}
@@ -0,0 +1,80 @@
+package o
+
+// Test bans on the broadest nolint forms
+// This file is tested with forbid-bare-nolint, forbid-empty-nolint and forbid-nolint-all enabled
+
+// Test case: bare nolint (should fail)
+func bareNolint() {
+	// want +1 "nolintguard: bare //nolint is forbidden; list the suppressed linters \\(//nolint:linter\\)"
+	//nolint
+	x := 1
+	_ = x
+}
+
+// Test case: bare nolint with explanation (should fail)
+func bareNolintWithExplanation() {
+	// want +1 "nolintguard: bare //nolint is forbidden; list the suppressed linters \\(//nolint:linter\\)"
+	//nolint // legacy code
+	x := 1
+	_ = x
+}
+
+// Test case: bare nolint followed by words (should fail)
+func bareNolintWithWords() {
+	// want +1 "nolintguard: bare //nolint is forbidden; list the suppressed linters \\(//nolint:linter\\)"
+	//nolint legacy code
+	x := 1
+	_ = x
+}
+
+// Test case: bare nolint after a space, followed by capitalized words (should fail)
+func bareNolintWithSpaceAndWords() {
+	// want +1 "nolintguard: bare //nolint is forbidden; list the suppressed linters \\(//nolint:linter\\)"
+	// nolint Because reasons
+	x := 1
+	_ = x
+}
+
+// Test case: empty linter list (should fail)
+func emptyList() {
+	// want +1 "nolintguard: //nolint: with an empty linter list is forbidden"
+	//nolint:
+	x := 1
+	_ = x
+}
+
+// Test case: linter list with only separators (should fail)
+func onlySeparators() {
+	// want +1 "nolintguard: //nolint: with an empty linter list is forbidden"
+	//nolint: , ,
+	x := 1
+	_ = x
+}
+
+// Test case: nolint:all (should fail)
+func nolintAll() {
+	//nolint:all // want "nolintguard: //nolint:all is forbidden; list the suppressed linters"
+	x := 1
+	_ = x
+}
+
+// Test case: all mixed with other linters (should fail)
+func nolintAllMixed() {
+	//nolint:errcheck,all // want "nolintguard: //nolint:all is forbidden; list the suppressed linters"
+	x := 1
+	_ = x
+}
+
+// Test case: specific linters (should pass)
+func specificLinters() {
+	//nolint:errcheck,lll
+	x := 1
+	_ = x
+}
+
+// Test case: linter whose name merely contains "all" (should pass)
+func similarName() {
+	//nolint:allocs
+	x := 1
+	_ = x
+}
@@ -0,0 +1,13 @@
+package payments
+
+// Test a later override matching legacy_*.go taking precedence over the
+// internal/payments override for the fields it sets
+
+import "os"
+
+func legacy() {
+	os.Remove("a") //nolint:errcheck // Allowed again in legacy code (should pass)
+
+	// want +1 "nolintguard: [#]nosec without rule IDs suppresses every gosec rule"
+	os.Remove("a") // #nosec -- Rule IDs are still required
+}
@@ -0,0 +1,26 @@
+package payments
+
+// Test the override for internal/payments: errcheck is forbidden and gosec
+// suppressions must list rule IDs other than G104
+
+import "os"
+
+func forbidden() {
+	// want +1 "nolintguard: //nolint:errcheck is forbidden"
+	os.Remove("a") //nolint:errcheck // Best effort cleanup
+}
+
+func ruleIDs() {
+	// want +1 "nolintguard: [#]nosec without rule IDs suppresses every gosec rule; list the suppressed rules \\(e.g., [#]nosec G401\\)"
+	os.Remove("a") // #nosec -- Best effort cleanup
+}
+
+func forbiddenRule() {
+	// want +1 "nolintguard: [#]nosec must not suppress gosec rule G104"
+	os.Remove("a") // #nosec G104 -- Best effort cleanup
+}
+
+func baseJustification() {
+	// want +1 "nolintguard: [#]nosec directive must include justification \\(-- reason\\)"
+	os.Remove("a") // #nosec G304
+}
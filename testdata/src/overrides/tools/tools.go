@@ -0,0 +1,16 @@
+package tools
+
+// Test the override for tools: no linters are forbidden and no justification is
+// required
+
+import "os"
+
+func loose() {
+	os.Remove("a") //nolint:staticcheck // Forbidden elsewhere (should pass)
+	os.Remove("a") // #nosec
+}
+
+func alwaysForbidden() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	os.Remove("a") //nolint:gosec
+}
@@ -0,0 +1,10 @@
+package tools
+
+// Test a pattern with a leading / matching from the module root only:
+// /overrides/tools/** does not match overridesroot/overrides/tools
+
+import "os"
+
+func notForbidden() {
+	os.Remove("a") //nolint:staticcheck // No override applies (should pass)
+}
@@ -0,0 +1,11 @@
+package payments
+
+// Test override patterns matching from the module root only: payments/** names
+// a directory nested below overridesroot, and src/** and testdata/** name the
+// directories the module is checked out in
+
+import "os"
+
+func notForbidden() {
+	os.Remove("a") //nolint:errcheck // No override applies (should pass)
+}
@@ -0,0 +1,60 @@
+package p
+
+// Test linter name validation
+// This file is tested with validate-linter-names=true and custom-linters=mycompanylint
+
+// Test case: known linters (should pass)
+func knownLinters() {
+	//nolint:errcheck,staticcheck,lll
+	x := 1
+	_ = x
+}
+
+// Test case: typo close to a known linter (should fail with suggestion)
+func typo() {
+	//nolint:errchek // want `nolintguard: //nolint:errchek names an unknown linter; did you mean "errcheck"\?`
+	x := 1
+	_ = x
+}
+
+// Test case: typo of gosec is reported as unknown instead of slipping through
+func gosecTypo() {
+	//nolint:gosecc // want `nolintguard: //nolint:gosecc names an unknown linter; did you mean "gosec"\?`
+	x := 1
+	_ = x
+}
+
+// Test case: name without any close match (should fail without suggestion)
+func noSuggestion() {
+	//nolint:completelymadeup // want `nolintguard: //nolint:completelymadeup names an unknown linter$`
+	x := 1
+	_ = x
+}
+
+// Test case: custom linter from the configuration (should pass)
+func customLinter() {
+	//nolint:mycompanylint
+	x := 1
+	_ = x
+}
+
+// Test case: typo of a custom linter (should fail with suggestion)
+func customTypo() {
+	//nolint:mycompanylnt // want `nolintguard: //nolint:mycompanylnt names an unknown linter; did you mean "mycompanylint"\?`
+	x := 1
+	_ = x
+}
+
+// Test case: all is a valid name (should pass)
+func nolintAll() {
+	//nolint:all
+	x := 1
+	_ = x
+}
+
+// Test case: unknown name among known ones
+func mixed() {
+	//nolint:lll,unusd,funlen // want `nolintguard: //nolint:unusd names an unknown linter; did you mean "unused"\?`
+	x := 1
+	_ = x
+}
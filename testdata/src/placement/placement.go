@@ -0,0 +1,66 @@
+package placement
+
+// Test //nolint directives attached to no code or to a whole compound statement
+
+import "os"
+
+// Test case: directive above a function declaration (should pass)
+//
+//nolint:funlen // Table of steps
+func steps() {
+	//nolint:errcheck // Best effort cleanup
+	os.Remove("a")
+
+	os.Remove("b") //nolint:errcheck // Best effort cleanup
+}
+
+// Test case: directive followed by a blank line
+func blankLine() {
+	// want +1 "nolintguard: //nolint:errcheck is not attached to any code; golangci-lint applies it to its own comment only"
+	//nolint:errcheck // Best effort cleanup
+
+	os.Remove("a")
+}
+
+// Test case: directive at the end of a block
+func endOfBlock() {
+	os.Remove("a")
+	// want +1 "nolintguard: //nolint:errcheck is not attached to any code"
+	//nolint:errcheck // Best effort cleanup
+}
+
+// Test case: directive in the middle of a doc comment (should pass, golangci-lint
+// matches the whole comment group)
+//
+//nolint:unused // Kept for reflection
+// unusedHelper is looked up by name.
+func unusedHelper() {}
+
+// Test case: directive above a whole if statement
+func wholeIf(path string) {
+	// want +1 "nolintguard: //nolint:errcheck applies to the whole if statement \\(lines 43-46\\); place it on the line that needs it"
+	//nolint:errcheck // Best effort cleanup
+	if path != "" {
+		os.Remove(path)
+		os.Remove(path + ".bak")
+	}
+}
+
+// Test case: directive above a whole range loop
+func wholeLoop(paths []string) {
+	// want +1 "nolintguard: //nolint:errcheck applies to the whole for statement \\(lines 53-55\\)"
+	//nolint:errcheck // Best effort cleanup
+	for _, path := range paths {
+		os.Remove(path)
+	}
+}
+
+// Test case: directives sharing their line with code or another comment (should pass)
+var ( //nolint:gochecknoglobals // Set once at startup
+	remove = os.Remove
+)
+
+func sharedLine() {
+	/* reviewed */ //nolint:errcheck // Best effort cleanup
+	remove("a")
+}
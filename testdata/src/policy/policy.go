@@ -0,0 +1,19 @@
+package policy
+
+// Test the policy read from the .nolintguard.yml file next to this package
+
+import "os"
+
+func forbidden() {
+	// want +1 "nolintguard: //nolint:errcheck is forbidden"
+	os.Remove("a") //nolint:errcheck // Best effort cleanup
+}
+
+func justification() {
+	// want +1 "nolintguard: #nosec directive must include justification \\(-- reason\\)"
+	os.Remove("a") // #nosec G104
+}
+
+func allowed() {
+	os.Remove("a") //nolint:unused // Not forbidden by the policy file (should pass)
+}
@@ -0,0 +1,20 @@
+package policyflags
+
+// Test flags overriding the policy read from the .nolintguard.json file next to
+// this package; the test sets -forbidden-linters=unused
+
+import "os"
+
+func overridden() {
+	os.Remove("a") //nolint:errcheck // Forbidden by the file, but the flag wins (should pass)
+}
+
+func forbiddenByFlag() {
+	// want +1 "nolintguard: //nolint:unused is forbidden"
+	os.Remove("a") //nolint:unused // Kept for reflection
+}
+
+func explanation() {
+	// want +1 "nolintguard: //nolint directive must include explanation \\(// reason\\)"
+	os.Remove("a") //nolint:lll
+}
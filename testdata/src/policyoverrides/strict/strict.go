@@ -0,0 +1,14 @@
+package strict
+
+// Test override patterns in a policy file matching relative to its directory
+
+import "os"
+
+func forbidden() {
+	// want +1 "nolintguard: //nolint:errcheck is forbidden"
+	os.Remove("a") //nolint:errcheck // Best effort cleanup
+}
+
+func allowed() {
+	os.Remove("a") //nolint:unused // Not matched by policyoverrides/** (should pass)
+}
@@ -0,0 +1,59 @@
+package q
+
+// Test deprecated and aliased linter names
+// This file is tested with forbidden-linters=staticcheck
+
+// Test case: gas is the old gosec name
+func gasAlias() {
+	// want +1 "nolintguard: //nolint:gas uses a deprecated linter name; use gosec instead" "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//nolint:gas
+	x := 1
+	_ = x
+}
+
+// Test case: golint is replaced by revive
+func golintAlias() {
+	// want +1 "nolintguard: //nolint:golint uses a deprecated linter name; use revive instead" "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	//nolint:golint // legacy API
+	x := 1
+	_ = x
+}
+
+// Test case: gosimple was merged into staticcheck and hits the forbidden list
+func gosimpleAlias() {
+	// want +1 "nolintguard: //nolint:gosimple uses a deprecated linter name; use staticcheck instead" "nolintguard: //nolint:staticcheck is forbidden"
+	//nolint:gosimple
+	x := 1
+	_ = x
+}
+
+// Test case: several aliases are renamed in one fix, keeping whitespace
+func severalAliases() {
+	// want +1 "nolintguard: //nolint:stylecheck uses a deprecated linter name; use staticcheck instead" "nolintguard: //nolint:megacheck uses a deprecated linter name; use staticcheck instead" "nolintguard: //nolint:staticcheck is forbidden" "nolintguard: //nolint:staticcheck is forbidden"
+	//nolint:errcheck, stylecheck ,megacheck // legacy
+	x := 1
+	_ = x
+}
+
+// Test case: block comment
+func blockComment() {
+	// want +1 "nolintguard: //nolint:vet uses a deprecated linter name; use govet instead"
+	/* nolint:vet */
+	x := 1
+	_ = x
+}
+
+// Test case: gosec rewrite also renames the residual linters
+func gasWithOthers() {
+	// want +1 "nolintguard: //nolint:gas uses a deprecated linter name; use gosec instead" "nolintguard: //nolint:gomnd uses a deprecated linter name; use mnd instead" "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//nolint:gas,gomnd
+	x := 1
+	_ = x
+}
+
+// Test case: canonical names are not reported
+func canonical() {
+	//nolint:errcheck,mnd
+	x := 1
+	_ = x
+}
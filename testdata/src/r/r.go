@@ -0,0 +1,123 @@
+package r
+
+// Test detection of obfuscated directives
+
+// Test case: mixed case nolint
+func mixedCase() {
+	// want +1 `nolintguard: obfuscated directive "NoLint:GOSEC" normalizes to "nolint:gosec"` "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//NoLint:GOSEC
+	x := 1
+	_ = x
+}
+
+// Test case: Cyrillic o in the linter name
+func cyrillicLetter() {
+	// want +1 `nolintguard: obfuscated directive "nolint:g\\u043esec" normalizes to "nolint:gosec"` "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//nolint:gоsec
+	x := 1
+	_ = x
+}
+
+// Test case: zero-width space in the linter name
+func zeroWidthSpace() {
+	// want +1 `nolintguard: obfuscated directive "nolint:go\\u200bsec" normalizes to "nolint:gosec"` "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//nolint:go​sec
+	x := 1
+	_ = x
+}
+
+// Test case: non-breaking space after //
+func nonBreakingSpace() {
+	// want +1 `nolintguard: obfuscated directive "\\u00a0nolint:gosec" normalizes to "nolint:gosec"` "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	// nolint:gosec
+	x := 1
+	_ = x
+}
+
+// Test case: full-width letters
+func fullWidth() {
+	// want +1 `nolintguard: obfuscated directive "\\uff4e\\uff4f\\uff4c\\uff49\\uff4e\\uff54:gosec" normalizes to "nolint:gosec"` "nolintguard: //nolint:gosec is forbidden; use #nosec or //gosec:disable instead"
+	//ｎｏｌｉｎｔ:gosec
+	x := 1
+	_ = x
+}
+
+// Test case: upper-case bare nolint
+func upperCaseBare() {
+	// want +1 `nolintguard: obfuscated directive "NOLINT" normalizes to "nolint"`
+	//NOLINT
+	x := 1
+	_ = x
+}
+
+// Test case: upper-case nosec keyword
+func upperCaseNosec() {
+	// want +1 `nolintguard: obfuscated directive "#NOSEC G401 -- reviewed" normalizes to "#nosec G401 -- reviewed"`
+	// #NOSEC G401 -- reviewed
+	x := 1
+	_ = x
+}
+
+// Test case: capitalized revive keyword
+func capitalizedRevive() {
+	// want +1 `nolintguard: obfuscated directive "Revive:disable-next-line legacy API" normalizes to "revive:disable-next-line legacy API"`
+	//Revive:disable-next-line legacy API
+	x := 1
+	_ = x
+}
+
+// Test case: non-ASCII nosec justification is fine
+func nosecRussianJustification() {
+	// #nosec G401 -- проверено
+	x := 1
+	_ = x
+}
+
+// Test case: non-ASCII revive justification is fine
+func reviveJustification() {
+	//revive:disable-next-line état légitime
+	x := 1
+	_ = x
+}
+
+// Test case: regular non-English comment is fine
+func regularComment() {
+	// Ноль по умолчанию
+	x := 1
+	_ = x
+}
+
+// Test case: capitalized prose mentioning nolint is fine
+func prose() {
+	// NolintGuard checks these directives
+	x := 1
+	_ = x
+}
+
+// Test case: upper-case rule IDs are fine
+func ruleIDs() {
+	//gosec:disable G401 -- reviewed
+	x := 1
+	_ = x
+}
+
+// Test case: capitalized prose starting with revive is fine
+func reviveProse() {
+	// Revive: restart the worker when it crashes.
+	x := 1
+	_ = x
+}
+
+// Test case: capitalized prose starting with nolint is fine
+func nolintProse() {
+	// Nolint: see docs for details.
+	x := 1
+	_ = x
+}
+
+// Test case: capitalized prose starting with gosec is fine
+func gosecProse() {
+	// Gosec: the scanner runs in CI.
+	x := 1
+	_ = x
+}
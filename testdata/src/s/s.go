@@ -0,0 +1,75 @@
+package s
+
+// Test directives stacked in a single comment
+// This file is tested with require-justification=true and forbidden-linters=staticcheck
+
+// Test case: second nolint hidden after an inline //
+func stackedNolint() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use [#]nosec or //gosec:disable instead"
+	//nolint:errcheck //nolint:gosec
+	x := 1
+	_ = x
+}
+
+// Test case: nolint hidden after a justified nosec directive
+func nolintAfterNosec() {
+	// want +1 "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	// #nosec G401 -- ok //nolint:revive
+	x := 1
+	_ = x
+}
+
+// Test case: forbidden linter hidden after an explanation
+func afterExplanation() {
+	// want +1 "nolintguard: //nolint:staticcheck is forbidden"
+	//nolint:errcheck // legacy code //nolint:staticcheck
+	x := 1
+	_ = x
+}
+
+// Test case: unjustified nosec hidden after a nolint directive
+func nosecAfterNolint() {
+	// want +1 "nolintguard: #nosec directive must include justification \\(-- reason\\)"
+	//nolint:errcheck // #nosec G401
+	x := 1
+	_ = x
+}
+
+// Test case: unjustified revive directive hidden after a nolint directive
+func reviveAfterNolint() {
+	// want +1 "nolintguard: //revive: directive must include justification \\(reason\\)"
+	//nolint:errcheck //revive:disable-line
+	x := 1
+	_ = x
+}
+
+// Test case: every directive in the comment is checked
+func threeDirectives() {
+	// want +1 "nolintguard: //nolint:gosec is forbidden; use [#]nosec or //gosec:disable instead" "nolintguard: //nolint:staticcheck is forbidden" "nolintguard: //nolint:revive is forbidden; use native revive directives instead"
+	//nolint:gosec //nolint:staticcheck //nolint:revive
+	x := 1
+	_ = x
+}
+
+// Test case: directives on separate lines of a block comment
+func blockComment() {
+	// want +2 "nolintguard: //nolint:gosec is forbidden; use [#]nosec or //gosec:disable instead"
+	/* nolint:errcheck
+	   nolint:gosec */
+	x := 1
+	_ = x
+}
+
+// Test case: an explanation that merely mentions a directive (should pass)
+func mentionInExplanation() {
+	//nolint:errcheck // see //nolint:gosec docs for details
+	x := 1
+	_ = x
+}
+
+// Test case: stacked directives that satisfy the policy (should pass)
+func compliantStack() {
+	//nolint:errcheck // #nosec G401 -- checksums only
+	x := 1
+	_ = x
+}
@@ -0,0 +1,87 @@
+package t
+
+// Test nosec tags anywhere in a comment and the alternative nosec tag
+
+import (
+	"crypto/md5"
+)
+
+// Test case: nosec after other text on the same line
+func nosecMidLine() {
+	// want +1 "nolintguard: #nosec directive must include justification \\(-- reason\\)"
+	h := md5.New() // legacy code #nosec G401
+	_ = h
+}
+
+// Test case: nosec after other text with a justification (should pass)
+func nosecMidLineJustified() {
+	h := md5.New() // legacy code #nosec G401 -- checksums only
+	_ = h
+}
+
+// Test case: nosec starting a later line of a comment group
+func nosecSecondLine() {
+	// legacy code
+	// #nosec G401 // want "nolintguard: #nosec directive must include justification \\(-- reason\\)"
+	h := md5.New()
+	_ = h
+}
+
+// Test case: nosec after an inline // of a nolint directive
+func nosecAfterNolint() {
+	// want +1 "nolintguard: #nosec directive must include justification \\(-- reason\\)"
+	h := md5.New() //nolint:errcheck // legacy code #nosec G401
+	_ = h
+}
+
+// Test case: nosec after other text, followed by more text
+func nosecMidLineWithText() {
+	// want +1 "nolintguard: #nosec directive must include justification \\(-- reason\\)"
+	h := md5.New() // legacy code #nosec G401 temporary
+	_ = h
+}
+
+// Test case: nosec after other text, followed by prose
+func nosecMidLineWithProse() {
+	// want +1 "nolintguard: #nosec directive must include justification \\(-- reason\\)"
+	h := md5.New() // legacy code #nosec because I said so
+	_ = h
+}
+
+// Test case: a tag glued to other text is not a directive (should pass)
+func nosecGlued() {
+	// The #nosecurity team and foo#nosec are not tags
+	h := md5.New()
+	_ = h
+}
+
+// Test case: alternative nosec tag without justification
+func altTag() {
+	// #falsepositive G401 // want "nolintguard: #falsepositive directive must include justification \\(-- reason\\)"
+	h := md5.New()
+	_ = h
+}
+
+// Test case: alternative nosec tag with justification (should pass)
+func altTagJustified() {
+	// #falsepositive G401 -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: alternative nosec tag after other text on the same line
+func altTagMidLine() {
+	// want +1 "nolintguard: #falsepositive directive must include justification \\(-- reason\\)"
+	h := md5.New() // legacy code #falsepositive
+	_ = h
+}
+
+// Test case: every tag in a block comment is checked
+func blockComment() {
+	// want +3 "nolintguard: #falsepositive directive must include justification \\(-- reason\\)"
+	// want +1 "nolintguard: #nosec directive must include justification \\(-- reason\\)"
+	/* legacy code #nosec G401
+	   #falsepositive G501 */
+	h := md5.New()
+	_ = h
+}
@@ -0,0 +1,14 @@
+package testfiles_test
+
+// Test the test-file policy applied to an external test package
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestExternal(t *testing.T) {
+	os.Remove("a") //nolint:errcheck // Allowed in tests (should pass)
+	_ = rand.Intn(10) // #nosec G404 -- Allowed in tests (should pass)
+}
@@ -0,0 +1,13 @@
+package testfiles
+
+// Test a path override applied after the test-file policy
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestStrict(t *testing.T) {
+	// want +1 "nolintguard: [#]nosec must not suppress gosec rule G404"
+	_ = rand.Intn(10) // #nosec G404 -- Forbidden again by the override
+}
@@ -0,0 +1,18 @@
+package testfiles
+
+// Test production code keeping the base policy
+
+import (
+	"math/rand"
+	"os"
+)
+
+func cleanup() {
+	// want +1 "nolintguard: //nolint:errcheck is forbidden"
+	os.Remove("a") //nolint:errcheck // Best effort cleanup
+}
+
+func jitter() int {
+	// want +1 "nolintguard: [#]nosec must not suppress gosec rule G404"
+	return rand.Intn(10) // #nosec G404 -- Jitter only
+}
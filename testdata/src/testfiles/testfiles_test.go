@@ -0,0 +1,19 @@
+package testfiles
+
+// Test the test-file policy applied to an internal test file
+
+import (
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestCleanup(t *testing.T) {
+	os.Remove("a") //nolint:errcheck // Allowed in tests (should pass)
+	_ = rand.Intn(10) // #nosec G404 -- Allowed in tests (should pass)
+}
+
+func TestStillForbidden(t *testing.T) {
+	// want +1 "nolintguard: //nolint:staticcheck is forbidden"
+	os.Remove("a") //nolint:staticcheck // Forbidden by the test-file policy
+}
@@ -0,0 +1,107 @@
+package u
+
+// Test gosec rule ID requirements for nosec and //gosec: directives
+
+import (
+	"crypto/md5"
+)
+
+// Test case: nosec with a known rule ID (should pass)
+func nosecKnownRule() {
+	// #nosec G401 -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: nosec with comma- and space-separated rule IDs (should pass)
+func nosecRuleList() {
+	// #nosec G401,G501 G505 -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: blanket nosec directive
+func nosecBlanket() {
+	// want +1 "nolintguard: [#]nosec without rule IDs suppresses every gosec rule; list the suppressed rules \\(e.g., [#]nosec G401\\)"
+	// #nosec -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: legacy block keyword is a blanket suppression
+func nosecBlock() {
+	// want +1 "nolintguard: [#]nosec without rule IDs suppresses every gosec rule"
+	// #nosec block -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: unknown rule ID
+func nosecUnknownRule() {
+	// want +1 "nolintguard: [#]nosec names unknown gosec rule G999"
+	// #nosec G401 G999 -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: malformed rule IDs
+func nosecMalformedRule() {
+	// want +1 "nolintguard: [#]nosec rule ID \"g401\" is malformed; gosec rule IDs have the form G123" "nolintguard: [#]nosec rule ID \"G40\" is malformed"
+	// #nosec g401 G40 -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: rule IDs separated by punctuation
+func nosecMalformedRuleSeparator() {
+	// want +1 "nolintguard: [#]nosec has a malformed separator at \"G401;G501\"; separate rule IDs with spaces or commas and the justification with --"
+	// #nosec G401;G501 -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: justification separated by a single dash
+func nosecMalformedJustificationSeparator() {
+	// want +1 "nolintguard: [#]nosec has a malformed separator at \"-\""
+	// #nosec G401 - checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: justification without any separator
+func nosecMissingJustificationSeparator() {
+	// want +1 "nolintguard: [#]nosec has a malformed separator at \"checksums\""
+	// #nosec G401 checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: //gosec:disable with a known rule ID (should pass)
+func gosecDisableKnownRule() {
+	//gosec:disable G401 -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: blanket gosec:disable directive
+func gosecDisableBlanket() {
+	// want +1 "nolintguard: //gosec:disable without rule IDs suppresses every gosec rule; list the suppressed rules \\(e.g., //gosec:disable G401\\)"
+	//gosec:disable -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: //gosec:enable without rule IDs re-enables everything (should pass)
+func gosecEnableAll() {
+	//gosec:enable
+	h := md5.New()
+	_ = h
+}
+
+// Test case: unknown rule ID in //gosec:enable
+func gosecEnableUnknownRule() {
+	// want +1 "nolintguard: //gosec:enable names unknown gosec rule G998"
+	//gosec:enable G998
+	h := md5.New()
+	_ = h
+}
@@ -0,0 +1,124 @@
+package v
+
+// Test gosec rules that must never be suppressed (G101, G201, G202, G402)
+
+import (
+	"crypto/md5"
+	"crypto/tls"
+	"database/sql"
+	"fmt"
+)
+
+// Test case: suppressing an allowed rule (should pass)
+func nosecAllowedRule() {
+	// #nosec G401 -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: suppressing a forbidden rule
+func nosecForbiddenRule() *tls.Config {
+	// want +1 "nolintguard: [#]nosec must not suppress gosec rule G402"
+	// #nosec G402 -- test servers only
+	return &tls.Config{InsecureSkipVerify: true}
+}
+
+// Test case: forbidden rule listed next to allowed ones
+func nosecForbiddenRuleInList() {
+	// want +1 "nolintguard: [#]nosec must not suppress gosec rule G101"
+	const password = "placeholder" // #nosec G401,G101 -- not a real credential
+	_ = password
+}
+
+// Test case: blanket suppression of code unlike any forbidden rule (should pass)
+func nosecBlanket() {
+	// #nosec -- checksums only
+	h := md5.New()
+	_ = h
+}
+
+// Test case: blanket suppression of a tls.Config literal
+func nosecBlanketTLSConfig() *tls.Config {
+	// want +1 "nolintguard: [#]nosec without rule IDs also suppresses forbidden gosec rules G402"
+	// #nosec -- test servers only
+	return &tls.Config{MinVersion: tls.VersionTLS10}
+}
+
+// Test case: blanket suppression of an InsecureSkipVerify assignment
+func nosecBlanketInsecureSkipVerify(config *tls.Config) {
+	// want +1 "nolintguard: [#]nosec without rule IDs also suppresses forbidden gosec rules G402"
+	config.InsecureSkipVerify = true // #nosec -- test servers only
+}
+
+// Test case: blanket suppression of a credential-like constant
+func nosecBlanketCredential() {
+	// want +1 "nolintguard: [#]nosec without rule IDs also suppresses forbidden gosec rules G101"
+	const apiToken = "placeholder" // #nosec -- not a real credential
+	_ = apiToken
+}
+
+// Test case: blanket suppression of a credential-like field
+func nosecBlanketCredentialField() any {
+	// want +1 "nolintguard: [#]nosec without rule IDs also suppresses forbidden gosec rules G101"
+	return struct{ Password string }{Password: "placeholder"} // #nosec -- not a real credential
+}
+
+// Test case: blanket suppression of an empty credential (should pass)
+func nosecBlanketEmptyCredential() {
+	password := "" // #nosec -- filled in later
+	_ = password
+}
+
+// Test case: blanket suppression of a query built with fmt.Sprintf
+func nosecBlanketSQLFormat(db *sql.DB, table string) {
+	// want +1 "nolintguard: [#]nosec without rule IDs also suppresses forbidden gosec rules G201"
+	// #nosec -- table names are fixed
+	rows, _ := db.Query(fmt.Sprintf("SELECT * FROM %s", table))
+	_ = rows
+}
+
+// Test case: blanket suppression of a query built by concatenation
+func nosecBlanketSQLConcatenation(db *sql.DB, table string) {
+	// want +1 "nolintguard: [#]nosec without rule IDs also suppresses forbidden gosec rules G202"
+	_, _ = db.Exec("DELETE FROM " + table) // #nosec -- table names are fixed
+}
+
+// Test case: blanket suppression of a query with placeholders (should pass)
+func nosecBlanketSQLPlaceholders(db *sql.DB, name string) {
+	// #nosec -- parameterized query
+	rows, _ := db.Query("SELECT * FROM users WHERE name = ?", name)
+	_ = rows
+}
+
+// Test case: blanket suppression covering several forbidden rules
+func nosecBlanketSeveral(db *sql.DB, table string) *tls.Config {
+	// want +1 "nolintguard: [#]nosec without rule IDs also suppresses forbidden gosec rules G202, G402"
+	// #nosec -- legacy code
+	if _, err := db.Exec("DELETE FROM " + table); err != nil {
+		return &tls.Config{InsecureSkipVerify: true}
+	}
+	return nil
+}
+
+// Test case: //gosec:disable with a forbidden rule
+func gosecDisableForbiddenRule() {
+	// want +1 "nolintguard: //gosec:disable must not suppress gosec rule G101"
+	//gosec:disable G101 -- not a real credential
+	const token = "placeholder"
+	_ = token
+}
+
+// Test case: blanket gosec:disable directive
+func gosecDisableBlanket() {
+	// want +1 "nolintguard: //gosec:disable without rule IDs also suppresses forbidden gosec rules G101"
+	//gosec:disable
+	const secret = "placeholder"
+	_ = secret
+}
+
+// Test case: //gosec:enable is never reported (should pass)
+func gosecEnable() {
+	//gosec:enable G101
+	h := md5.New()
+	_ = h
+}
@@ -0,0 +1,51 @@
+package w
+
+// Test revive rule name validation and rules that must never be disabled
+
+// Test case: known rule (should pass)
+//
+//revive:disable-next-line:var-naming Matches the wire format
+var user_id int
+
+// Test case: comma-separated known rules (should pass)
+//
+//revive:disable-next-line:var-naming,unused-parameter Matches the wire format
+func handler(user_name string) {}
+
+// Test case: misspelled rule
+//
+// want +1 "nolintguard: \\/\\/revive:disable-next-line:var-nameing names an unknown revive rule; did you mean \"var-naming\"\\?"
+//revive:disable-next-line:var-nameing Matches the wire format
+var session_id int
+
+// Test case: unknown rule in a comma-separated list
+//
+// want +1 "nolintguard: \\/\\/revive:disable:no-such-rule names an unknown revive rule$"
+//revive:disable:var-naming,no-such-rule Legacy names
+var legacy_name int
+
+//revive:enable:var-naming
+
+// Test case: forbidden rule
+//
+// want +1 "nolintguard: \\/\\/revive:disable-line:exported is forbidden"
+func Exported() {} //revive:disable-line:exported Internal API
+
+// Test case: forbidden rule in a comma-separated list
+//
+// want +1 "nolintguard: \\/\\/revive:disable:exported is forbidden"
+//revive:disable:var-naming,exported Generated code
+func Generated() {}
+
+//revive:enable:var-naming,exported
+
+// Test case: disabling every rule also disables forbidden ones
+//
+// want +1 "nolintguard: \\/\\/revive:disable without rule names also disables forbidden revive rules exported"
+//revive:disable Generated code
+func AllDisabled() {}
+
+// Test case: enabling a forbidden rule is fine (should pass)
+//
+//revive:enable
+func AllEnabled() {}
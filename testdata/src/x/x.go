@@ -0,0 +1,58 @@
+package x
+
+// Test unbalanced revive disable and enable regions
+
+// Test case: balanced region (should pass)
+//
+//revive:disable:var-naming Matches the wire format
+var user_id int
+
+//revive:enable:var-naming
+
+// Test case: single-line directives do not open regions (should pass)
+//
+//revive:disable-next-line:var-naming Matches the wire format
+var session_id int
+
+// Test case: enable without a preceding disable
+//
+// want +1 "nolintguard: \\/\\/revive:enable:exported without a preceding \\/\\/revive:disable"
+//revive:enable:exported
+
+// Test case: redundant nested disable of the same rule
+//
+//revive:disable:var-naming Legacy names
+var legacy_id int
+
+// want +1 "nolintguard: \\/\\/revive:disable:var-naming is redundant; the rule is already disabled"
+//revive:disable:var-naming Still legacy names
+var legacy_name int
+
+//revive:enable:var-naming
+
+// Test case: re-disabling a rule enabled inside a blanket region (should pass)
+//
+//revive:disable Generated code
+var generated_id int
+
+//revive:enable:var-naming
+//revive:disable:var-naming Generated code
+
+// Test case: nested disable inside a blanket region
+//
+// want +1 "nolintguard: \\/\\/revive:disable:exported is redundant; every revive rule is already disabled"
+//revive:disable:exported Generated code
+func Generated() {}
+
+//revive:enable
+
+// Test case: blanket enable without any open region
+//
+// want +1 "nolintguard: \\/\\/revive:enable without a preceding \\/\\/revive:disable"
+//revive:enable
+
+// Test case: region never re-enabled
+//
+// want +1 "nolintguard: \\/\\/revive:disable:unused-parameter is never re-enabled; the rule stays disabled for the rest of the file"
+//revive:disable:unused-parameter Callbacks share a signature
+func callback(name string) {}
@@ -0,0 +1,82 @@
+package y
+
+// Test limits on the number of lines a single directive may suppress
+
+import (
+	"crypto/md5"
+	"os"
+)
+
+// Test case: nolint on a short function (should pass)
+//
+//nolint:errcheck // Best effort cleanup
+func short() {
+	os.Remove("a")
+}
+
+// Test case: nolint on its own line covers the whole declaration
+//
+// want +1 "nolintguard: //nolint:funlen suppresses 6 lines \\(20-25\\), more than the limit of 4"
+//nolint:funlen // Table of steps
+func long() {
+	os.Remove("a")
+	os.Remove("b")
+	os.Remove("c")
+}
+
+// Test case: trailing nolint only covers its own line (should pass)
+func trailing() {
+	if err := os.Remove("a"); err != nil { //nolint:staticcheck // Empty branch kept for clarity
+		os.Remove("b")
+		os.Remove("c")
+		os.Remove("d")
+	}
+}
+
+// Test case: nosec attached to a statement (should pass)
+func nosecStatement() {
+	h := md5.New() // #nosec G401 -- checksums only
+	_ = h
+}
+
+// Test case: nosec in a doc comment covers the whole function
+//
+// want +1 "nolintguard: [#]nosec suppresses 7 lines \\(45-51\\), more than the limit of 3"
+// #nosec G401 -- checksums only
+func nosecFunction() {
+	a := md5.New()
+	b := md5.New()
+	c := md5.New()
+	_, _, _ = a, b, c
+}
+
+// Test case: short revive region (should pass)
+//
+//revive:disable:var-naming Matches the wire format
+var user_id int
+
+//revive:enable:var-naming
+
+// Test case: revive region spanning too many lines
+//
+// want +1 "nolintguard: //revive:disable:var-naming suppresses 8 lines \\(63-70\\), more than the limit of 5"
+//revive:disable:var-naming Matches the wire format
+var (
+	session_id int
+	device_id  int
+	account_id int
+)
+
+//revive:enable:var-naming
+
+// Test case: region never closed spans to the end of the file
+//
+// want +1 "nolintguard: //revive:disable:unused-parameter suppresses 8 lines \\(75-82\\), more than the limit of 5"
+//revive:disable:unused-parameter Callbacks share a signature
+func callback(name string) {}
+
+func callback2(name string) {}
+
+func callback3(name string) {}
+
+func callback4(name string) {}
@@ -0,0 +1,14 @@
+//nolint:lll // Long generated URLs
+package z
+
+// Test file-level suppressions that are allowed or not file-level (should pass)
+
+//nolint:errcheck // Separated by a blank line, so it is attached to nothing
+
+//revive:disable:var-naming Matches the wire format
+var device_id int
+
+//revive:enable:var-naming
+
+// #nosec G401 -- Attached to the declaration only
+var account_id int
@@ -0,0 +1,11 @@
+// want +1 "nolintguard: //nolint:errcheck,lll applies to the whole file; file-level suppression of errcheck is forbidden"
+//nolint:errcheck,lll // Generated bindings
+package z
+
+// Test file-level suppressions: //nolint directly above the package clause
+
+import "os"
+
+func remove() {
+	os.Remove("a")
+}
@@ -0,0 +1,12 @@
+// Package z tests file-level suppressions.
+//
+// want +1 "nolintguard: [#]nosec applies to the whole file; file-level suppression of gosec is forbidden"
+// #nosec G401 -- checksums only
+package z
+
+import "crypto/md5"
+
+func checksum() {
+	h := md5.New()
+	_ = h
+}
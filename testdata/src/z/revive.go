@@ -0,0 +1,8 @@
+// want +1 "nolintguard: //revive:disable:var-naming applies to the whole file; file-level suppression of revive is forbidden"
+//revive:disable:var-naming Matches the wire format
+
+package z
+
+var user_id int
+
+var session_id int